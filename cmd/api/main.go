@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	httpadapter "rankit/internal/adapters/http"
 	"rankit/internal/adapters/http/handlers"
@@ -55,10 +57,16 @@ func main() {
 	quizRepo := persistence.NewSQLiteQuizRepository(db)
 	questionRepo := persistence.NewSQLiteQuestionRepository(db)
 
-	// Novo - Repositório In-Memory
-	gameRepo := persistence.NewInMemoryGameRepository()
+	// Salas ao vivo: memória + snapshot no SQLite (sobrevivem a reinícios)
+	gameRepo := persistence.NewSQLiteGameRepository(db)
+	restored, err := gameRepo.LoadActiveRooms(context.Background())
+	if err != nil {
+		logger.Error("Falha ao restaurar salas ativas", "erro", err)
+		os.Exit(1)
+	}
 	// Novo - Repositório Histórico
 	historyRepo := persistence.NewSQLiteHistoryRepository(db)
+	assignmentRepo := persistence.NewSQLiteAssignmentRepository(db)
 
 	hasher := security.NewBcryptHasher()
 	tokenService := security.NewJWTService(cfg.JWTSecret)
@@ -78,7 +86,24 @@ func main() {
 
 	// Novo - Use Case de Jogo
 	historyUC := usecases.NewHistoryUseCases(historyRepo, gameRepo)
-	gameUC := usecases.NewGameUseCases(gameRepo, quizRepo, wsHub, historyUC)
+	lifecycle := usecases.NewRoomLifecycleManager(
+		gameRepo, wsHub, historyUC,
+		cfg.Rooms.IdleTTL, cfg.Rooms.MaxTTL, cfg.Rooms.SweepInterval,
+	)
+	gameUC := usecases.NewGameUseCases(gameRepo, quizRepo, wsHub, historyUC, tokenService, lifecycle)
+	if err := gameUC.ResumeRooms(); err != nil {
+		logger.Error("Falha ao retomar salas ativas", "erro", err)
+		os.Exit(1)
+	}
+	logger.Info("Salas ativas restauradas", "total", restored)
+
+	// Lição de casa: independe das salas ao vivo
+	assignmentUC := usecases.NewAssignmentUseCases(assignmentRepo, quizRepo, historyRepo)
+
+	// Varredura de salas expiradas em background
+	go lifecycle.Run(context.Background())
+	// Tarefas que chegam ao prazo são arquivadas no histórico pela mesma cadência
+	go assignmentUC.Run(context.Background(), cfg.Rooms.SweepInterval)
 
 	// 5. Adapters (Driven - Handlers)
 	authHandler := handlers.NewAuthHandler(registerUC, loginUC, getMeUC)
@@ -86,8 +111,9 @@ func main() {
 	questionHandler := handlers.NewQuestionHandler(questionUC)
 	gameHandler := handlers.NewGameHandler(gameUC)
 	reportHandler := handlers.NewReportHandler(historyUC)
+	assignmentHandler := handlers.NewAssignmentHandler(assignmentUC)
 
-	wsHandler := websocket.NewWebSocketHandler(wsHub, gameUC)
+	wsHandler := websocket.NewWebSocketHandler(wsHub, gameUC, tokenService)
 
 	// 6. Router
 	router := httpadapter.NewRouter(
@@ -96,6 +122,7 @@ func main() {
 		questionHandler,
 		gameHandler,
 		reportHandler,
+		assignmentHandler,
 		wsHandler,
 		tokenService,
 	)
@@ -108,6 +135,14 @@ func main() {
 }
 
 func runMigrations(db *sql.DB) error {
+	// Controle de migrações aplicadas (ALTER TABLE não é idempotente no SQLite)
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		filename TEXT PRIMARY KEY,
+		applied_at DATETIME NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("erro ao criar schema_migrations: %w", err)
+	}
+
 	files, err := os.ReadDir("migrations")
 	if err != nil {
 		return fmt.Errorf("erro ao ler diretório migrations: %w", err)
@@ -122,6 +157,14 @@ func runMigrations(db *sql.DB) error {
 	sort.Strings(filenames)
 
 	for _, filename := range filenames {
+		var applied int
+		if err := db.QueryRow("SELECT COUNT(1) FROM schema_migrations WHERE filename = ?", filename).Scan(&applied); err != nil {
+			return fmt.Errorf("erro ao consultar schema_migrations: %w", err)
+		}
+		if applied > 0 {
+			continue
+		}
+
 		path := filepath.Join("migrations", filename)
 		content, err := os.ReadFile(path)
 		if err != nil {
@@ -132,6 +175,9 @@ func runMigrations(db *sql.DB) error {
 		if _, err := db.Exec(string(content)); err != nil {
 			return fmt.Errorf("erro ao executar %s: %w", filename, err)
 		}
+		if _, err := db.Exec("INSERT INTO schema_migrations (filename, applied_at) VALUES (?, ?)", filename, time.Now()); err != nil {
+			return fmt.Errorf("erro ao registrar %s: %w", filename, err)
+		}
 	}
 	return nil
 }
@@ -17,6 +17,183 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/assignments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignments"
+                ],
+                "summary": "Lista tarefas do professor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/assignment.Assignment"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Cria uma tarefa a partir de um quiz PUBLISHED. Os alunos entram pelo código e respondem no próprio ritmo até closesAt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignments"
+                ],
+                "summary": "Passa um quiz como tarefa (lição de casa)",
+                "parameters": [
+                    {
+                        "description": "payload: {quizId: uuid, title?: string, opensAt?: RFC3339, closesAt: RFC3339}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.CreateAssignmentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/assignment.Assignment"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/assignments/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignments"
+                ],
+                "summary": "Detalha uma tarefa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da Tarefa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assignment.Assignment"
+                        }
+                    },
+                    "404": {
+                        "description": "Tarefa não encontrada"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/assignments/{id}/close": {
+            "post": {
+                "description": "Antecipa o prazo (se ainda aberta) e grava o resultado no histórico (GET /reports/rooms). O historyId fica na tarefa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignments"
+                ],
+                "summary": "Encerra e arquiva a tarefa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da Tarefa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assignment.Assignment"
+                        }
+                    },
+                    "404": {
+                        "description": "Tarefa não encontrada"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/assignments/{id}/report": {
+            "get": {
+                "description": "Resultado das tentativas até o momento, no mesmo formato do histórico das salas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignments"
+                ],
+                "summary": "Relatório da tarefa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da Tarefa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/history.RoomHistory"
+                        }
+                    },
+                    "404": {
+                        "description": "Tarefa não encontrada"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
         "/auth/login": {
             "post": {
                 "description": "Realiza login com email e senha e retorna um token JWT.",
@@ -71,11 +248,6 @@ const docTemplate = `{
         },
         "/auth/me": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
                 "description": "Obtém detalhes do perfil do usuário autenticado via token JWT.",
                 "consumes": [
                     "application/json"
@@ -112,7 +284,12 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/auth/register": {
@@ -176,40 +353,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/quizzes": {
+        "/homework/attempts/{token}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retorna todos os quizzes do professor logado.",
+                "description": "Respostas enviadas e pontuação. O gabarito (answerKey) só aparece depois que a tarefa é encerrada, pelo prazo ou pelo professor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Quizzes"
+                    "Homework"
+                ],
+                "summary": "Progresso da tentativa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "attemptToken",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Lista quizzes do professor",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/quiz.Quiz"
-                            }
+                            "$ref": "#/definitions/usecases.AttemptReview"
                         }
+                    },
+                    "404": {
+                        "description": "Tentativa não encontrada"
                     }
                 }
-            },
+            }
+        },
+        "/homework/attempts/{token}/answers": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Cria um quiz vinculado ao professor logado. Status inicial DRAFT.",
+                "description": "Os campos de resposta seguem o evento submit_answer do WebSocket (posições exibidas em ORDERING/MATCHING). A correção informa acerto e pontos, mas não o gabarito: ele só é liberado em GET /homework/attempts/{token} após o encerramento da tarefa.",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,77 +395,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Quizzes"
+                    "Homework"
                 ],
-                "summary": "Cria um novo quiz (Rascunho)",
+                "summary": "Responde a pergunta atual",
                 "parameters": [
                     {
-                        "description": "Dados do Quiz",
+                        "type": "string",
+                        "description": "attemptToken",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload: {questionIndex: int, answerIndex?: int, answerIndexes?: [int], answerText?: string, answerOrder?: [int], answerPairs?: [[int,int]]}",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usecases.CreateQuizInput"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/quiz.Quiz"
+                            "$ref": "#/definitions/usecases.HomeworkAnswerResult"
                         }
                     },
                     "400": {
-                        "description": "Erro de validação",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "description": "Resposta inválida"
                     },
-                    "401": {
-                        "description": "Não autorizado",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Erro interno",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "409": {
+                        "description": "Pergunta fora de ordem ou tarefa fora do prazo"
                     }
                 }
             }
         },
-        "/quizzes/{id}": {
+        "/homework/attempts/{token}/question": {
             "get": {
-                "security": [
+                "description": "Retorna a pergunta atual sem o gabarito (question ausente quando a tentativa terminou).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Homework"
+                ],
+                "summary": "Próxima pergunta da tentativa",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "attemptToken",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Retorna dados do quiz e suas perguntas.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.HomeworkQuestion"
+                        }
+                    },
+                    "409": {
+                        "description": "Tarefa fora do prazo"
+                    }
+                }
+            }
+        },
+        "/homework/{code}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Quizzes"
+                    "Homework"
                 ],
-                "summary": "Detalha um quiz",
+                "summary": "Dados públicos da tarefa",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do Quiz",
-                        "name": "id",
+                        "description": "Código da Tarefa",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -296,11 +487,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/quiz.Quiz"
+                            "$ref": "#/definitions/usecases.HomeworkInfo"
                         }
                     },
                     "404": {
-                        "description": "Não encontrado",
+                        "description": "Tarefa não encontrada"
+                    }
+                }
+            }
+        },
+        "/homework/{code}/attempts": {
+            "post": {
+                "description": "Retorna o attemptToken usado nas chamadas seguintes (guardar para continuar depois).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Homework"
+                ],
+                "summary": "Inicia a tentativa do aluno",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código da Tarefa",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload: {nickname: string}",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -308,14 +529,155 @@ const docTemplate = `{
                             }
                         }
                     }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecases.StartAttemptOutput"
+                        }
+                    },
+                    "409": {
+                        "description": "Tarefa fora do prazo ou apelido em uso"
+                    }
                 }
+            }
+        },
+        "/quizzes": {
+            "get": {
+                "description": "Retorna todos os quizzes do professor logado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Lista quizzes do professor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/quiz.Quiz"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
-            "put": {
+            "post": {
+                "description": "Cria um quiz vinculado ao professor logado. Status inicial DRAFT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Cria um novo quiz (Rascunho)",
+                "parameters": [
+                    {
+                        "description": "Dados do Quiz",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecases.CreateQuizInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/quiz.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/quizzes/{id}": {
+            "get": {
+                "description": "Retorna dados do quiz e suas perguntas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quizzes"
+                ],
+                "summary": "Detalha um quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Quiz",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/quiz.Quiz"
+                        }
+                    },
+                    "404": {
+                        "description": "Não encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
                 "description": "Atualiza título, descrição, etc. Apenas se DRAFT.",
                 "consumes": [
                     "application/json"
@@ -361,14 +723,14 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "Remove quiz e perguntas. Apenas se DRAFT.",
                 "tags": [
                     "Quizzes"
@@ -390,16 +752,16 @@ const docTemplate = `{
                     "400": {
                         "description": "Não pode deletar quiz publicado"
                     }
-                }
-            }
-        },
-        "/quizzes/{id}/publish": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/quizzes/{id}/publish": {
+            "post": {
                 "description": "Altera status para PUBLISHED. Valida perguntas.",
                 "tags": [
                     "Quizzes"
@@ -430,16 +792,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/quizzes/{id}/questions": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/quizzes/{id}/questions": {
+            "post": {
                 "description": "Adiciona uma nova pergunta ao quiz em DRAFT.",
                 "consumes": [
                     "application/json"
@@ -479,16 +841,16 @@ const docTemplate = `{
                     "400": {
                         "description": "Dados inválidos"
                     }
-                }
-            }
-        },
-        "/quizzes/{id}/questions/reorder": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/quizzes/{id}/questions/reorder": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -524,16 +886,16 @@ const docTemplate = `{
                     "200": {
                         "description": "OK"
                     }
-                }
-            }
-        },
-        "/quizzes/{id}/questions/{questionId}": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/quizzes/{id}/questions/{questionId}": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -576,14 +938,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/quiz.Question"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "tags": [
                     "Questions"
                 ],
@@ -608,16 +970,16 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     }
-                }
-            }
-        },
-        "/reports/quizzes/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/reports/quizzes/{id}": {
+            "get": {
                 "description": "Retorna métricas agregadas de um quiz.",
                 "produces": [
                     "application/json"
@@ -643,16 +1005,16 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/reports/rooms": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/reports/rooms": {
+            "get": {
                 "description": "Lista salas finalizadas do professor logado.",
                 "produces": [
                     "application/json"
@@ -685,16 +1047,16 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
-            }
-        },
-        "/reports/rooms/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/reports/rooms/{id}": {
+            "get": {
                 "description": "Retorna detalhes completos de uma sala, incluindo ranking e stats.",
                 "produces": [
                     "application/json"
@@ -722,17 +1084,17 @@ const docTemplate = `{
                     "404": {
                         "description": "Sala não encontrada"
                     }
-                }
-            }
-        },
-        "/rooms": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Cria uma nova sala a partir de um quiz PUBLISHED.",
+                ]
+            }
+        },
+        "/rooms": {
+            "post": {
+                "description": "Cria uma nova sala a partir de um quiz PUBLISHED. Opcionalmente habilita o modo times, embaralha perguntas e alternativas e define as regras de entrada (entrada tardia, limite de alunos e aprovação automática).",
                 "consumes": [
                     "application/json"
                 ],
@@ -745,15 +1107,13 @@ const docTemplate = `{
                 "summary": "Cria uma sala de jogo",
                 "parameters": [
                     {
-                        "description": "payload: {quizId: uuid}",
+                        "description": "payload: {quizId: uuid, teamMode?: bool, teamScoring?: SUM|AVERAGE, autoBalanceTeams?: bool, teams?: [string], shuffleQuestions?: bool, shuffleOptions?: bool, lateJoin?: DENY|ZERO|AVERAGE, maxPlayers?: int, autoApprove?: bool, answerPolicy?: CHANGEABLE|FIRST_FINAL}",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 ],
@@ -761,17 +1121,23 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/game.Room"
+                            "$ref": "#/definitions/game.PublicRoom"
                         }
                     },
                     "400": {
                         "description": "Quiz inválido"
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/rooms/{id}": {
             "get": {
+                "description": "Aceita o ID interno ou o código numérico de entrada da sala. Retorna apenas dados públicos: a pergunta atual sem gabarito e os alunos pelo apelido, sem as respostas.",
                 "produces": [
                     "application/json"
                 ],
@@ -782,7 +1148,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID",
+                        "description": "Room ID ou código",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -792,59 +1158,228 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/game.Room"
+                            "$ref": "#/definitions/game.PublicRoom"
                         }
                     },
                     "404": {
                         "description": "Sala não encontrada"
                     }
                 }
+            },
+            "delete": {
+                "description": "Arquiva a sala (como ABANDONED se o quiz não terminou), desconecta os participantes e remove a sala.",
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Encerra uma sala de jogo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Sala não encontrada"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/rooms/{id}/display-code": {
+            "post": {
+                "description": "Autoriza um projetor a acompanhar a sala via WebSocket (/ws?roomId=...\u0026display=\u003ccódigo\u003e). A tela não entra como aluno nem pode responder. Gerar um novo código invalida o anterior.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rooms"
+                ],
+                "summary": "Gera o código da tela de projeção",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "displayCode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Sala não encontrada"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         }
     },
     "definitions": {
-        "game.Answer": {
+        "assignment.Assignment": {
+            "type": "object",
+            "properties": {
+                "closesAt": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Código compartilhado com os alunos",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "historyId": {
+                    "description": "Registro em rooms_history após o arquivamento",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opensAt": {
+                    "type": "string"
+                },
+                "quizId": {
+                    "type": "string"
+                },
+                "teacherId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "assignment.Attempt": {
             "type": "object",
             "properties": {
-                "answerIndex": {
-                    "description": "0..3",
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assignment.AttemptAnswer"
+                    }
+                },
+                "assignmentId": {
+                    "type": "string"
+                },
+                "currentIndex": {
+                    "description": "Próxima pergunta a responder",
                     "type": "integer"
                 },
-                "playerID": {
+                "finishedAt": {
+                    "description": "Zero enquanto a tentativa está em andamento",
                     "type": "string"
                 },
-                "submittedAt": {
+                "id": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "startedAt": {
                     "type": "string"
                 }
             }
         },
-        "game.Player": {
+        "assignment.AttemptAnswer": {
             "type": "object",
             "properties": {
-                "connected": {
+                "answeredAt": {
+                    "type": "string"
+                },
+                "attemptId": {
+                    "type": "string"
+                },
+                "correct": {
                     "type": "boolean"
                 },
+                "credit": {
+                    "description": "De 0 a 1 (crédito parcial)",
+                    "type": "number"
+                },
                 "id": {
-                    "description": "Session ID / Socket ID",
                     "type": "string"
                 },
+                "points": {
+                    "type": "integer"
+                },
+                "questionIndex": {
+                    "type": "integer"
+                },
+                "response": {
+                    "description": "Índices originais da pergunta",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/quiz.Response"
+                        }
+                    ]
+                }
+            }
+        },
+        "game.PublicPlayer": {
+            "type": "object",
+            "properties": {
                 "nickname": {
                     "type": "string"
                 },
+                "previousRank": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
                 "score": {
                     "type": "integer"
+                },
+                "streak": {
+                    "type": "integer"
+                },
+                "teamId": {
+                    "type": "string"
                 }
             }
         },
-        "game.Room": {
+        "game.PublicRoom": {
             "type": "object",
             "properties": {
-                "answers": {
-                    "description": "Map[PlayerID]*Answer (da pergunta atual)",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/game.Answer"
-                    }
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentQuestion": {
+                    "description": "Sempre sem gabarito",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/quiz.Question"
+                        }
+                    ]
                 },
                 "currentQuestionIndex": {
                     "type": "integer"
@@ -853,19 +1388,84 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "players": {
-                    "description": "Map[PlayerID]*Player",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/game.Player"
+                    "description": "Na ordem do placar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/game.PublicPlayer"
                     }
                 },
-                "quiz": {
-                    "$ref": "#/definitions/quiz.Quiz"
+                "settings": {
+                    "$ref": "#/definitions/game.RoomSettings"
                 },
                 "status": {
                     "type": "string"
                 },
-                "teacherID": {
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/game.Team"
+                    }
+                },
+                "totalQuestions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "game.RoomSettings": {
+            "type": "object",
+            "properties": {
+                "answerPolicy": {
+                    "description": "CHANGEABLE (padrão) ou FIRST_FINAL",
+                    "type": "string"
+                },
+                "autoApprove": {
+                    "description": "Aprova a entrada sem moderação do professor",
+                    "type": "boolean"
+                },
+                "autoBalanceTeams": {
+                    "description": "Distribui os alunos aprovados no time com menos membros",
+                    "type": "boolean"
+                },
+                "lateJoin": {
+                    "description": "DENY (padrão), ZERO ou AVERAGE",
+                    "type": "string"
+                },
+                "maxPlayers": {
+                    "description": "Limite de alunos aprovados (0 = sem limite)",
+                    "type": "integer"
+                },
+                "shuffleOptions": {
+                    "description": "Alternativas em ordem aleatória para cada aluno",
+                    "type": "boolean"
+                },
+                "shuffleQuestions": {
+                    "description": "Perguntas em ordem aleatória (a mesma para toda a sala)",
+                    "type": "boolean"
+                },
+                "teamMode": {
+                    "description": "Jogo em times",
+                    "type": "boolean"
+                },
+                "teamScoring": {
+                    "description": "SUM (padrão) ou AVERAGE",
+                    "type": "string"
+                },
+                "teams": {
+                    "description": "Times iniciais (podem ser redefinidos no lobby)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "game.Team": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
@@ -873,12 +1473,37 @@ const docTemplate = `{
         "history.PlayerAnswer": {
             "type": "object",
             "properties": {
+                "answerText": {
+                    "description": "Texto digitado (OPEN_TEXT e NUMERIC)",
+                    "type": "string"
+                },
+                "changeCount": {
+                    "description": "Quantas vezes o aluno trocou a resposta",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
                 "isCorrect": {
                     "type": "boolean"
                 },
+                "order": {
+                    "description": "ORDERING: itens na ordem escolhida",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pairs": {
+                    "description": "MATCHING: pares [esquerda, direita]",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
                 "questionIndex": {
                     "type": "integer"
                 },
@@ -888,9 +1513,12 @@ const docTemplate = `{
                 "roomPlayerId": {
                     "type": "string"
                 },
-                "selectedIndex": {
-                    "description": "-1 se não respondeu?",
-                    "type": "integer"
+                "selectedIndexes": {
+                    "description": "Vazio se não respondeu",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
@@ -915,6 +1543,10 @@ const docTemplate = `{
                 "score": {
                     "type": "integer"
                 },
+                "teamId": {
+                    "description": "TeamStats.TeamRuntimeID do time do aluno",
+                    "type": "string"
+                },
                 "wrongCount": {
                     "type": "integer"
                 }
@@ -926,24 +1558,22 @@ const docTemplate = `{
                 "correctCount": {
                     "type": "integer"
                 },
-                "correctIndex": {
-                    "type": "integer"
-                },
-                "countA": {
-                    "type": "integer"
-                },
-                "countB": {
-                    "type": "integer"
-                },
-                "countC": {
-                    "type": "integer"
-                },
-                "countD": {
-                    "type": "integer"
+                "correctIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
                 "id": {
                     "type": "string"
                 },
+                "optionCounts": {
+                    "description": "Quantos alunos marcaram cada alternativa",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "promptSnapshot": {
                     "type": "string"
                 },
@@ -953,8 +1583,15 @@ const docTemplate = `{
                 "questionIndex": {
                     "type": "integer"
                 },
+                "questionType": {
+                    "type": "string"
+                },
                 "roomHistoryId": {
                     "type": "string"
+                },
+                "skipped": {
+                    "description": "Pulada pelo professor (não pontuou)",
+                    "type": "boolean"
                 }
             }
         },
@@ -997,26 +1634,69 @@ const docTemplate = `{
                 "roomId": {
                     "type": "string"
                 },
-                "startedAt": {
-                    "type": "string"
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "teacherId": {
+                    "type": "string"
+                },
+                "teamScoring": {
+                    "description": "SUM ou AVERAGE (vazio fora do modo times)",
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/history.TeamStats"
+                    }
+                },
+                "totalQuestions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "history.TeamStats": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "memberCount": {
+                    "type": "integer"
                 },
-                "status": {
+                "name": {
                     "type": "string"
                 },
-                "teacherId": {
+                "roomHistoryId": {
                     "type": "string"
                 },
-                "totalQuestions": {
+                "score": {
                     "type": "integer"
+                },
+                "teamRuntimeId": {
+                    "type": "string"
                 }
             }
         },
         "quiz.Question": {
             "type": "object",
             "properties": {
-                "correctIndex": {
-                    "description": "0..3",
-                    "type": "integer"
+                "acceptedAnswers": {
+                    "description": "OPEN_TEXT: respostas aceitas (sem diferenciar maiúsculas, acentos e espaços)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "correctIndexes": {
+                    "description": "Índices das alternativas corretas",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
                 "createdAt": {
                     "type": "string"
@@ -1024,21 +1704,41 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "optionA": {
-                    "description": "0",
-                    "type": "string"
+                "items": {
+                    "description": "ORDERING: itens na ordem correta",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "optionB": {
-                    "description": "1",
-                    "type": "string"
+                "matchLeft": {
+                    "description": "MATCHING: coluna da esquerda",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "optionC": {
-                    "description": "2",
-                    "type": "string"
+                "matchRight": {
+                    "description": "MATCHING: coluna da direita (MatchRight[i] corresponde a MatchLeft[i])",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "optionD": {
-                    "description": "3",
-                    "type": "string"
+                "numericAnswer": {
+                    "description": "NUMERIC: valor correto",
+                    "type": "number"
+                },
+                "options": {
+                    "description": "Alternativas, na ordem exibida",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pointsMultiplier": {
+                    "description": "Multiplica os pontos da pergunta (1 = normal, 2 = em dobro)",
+                    "type": "integer"
                 },
                 "prompt": {
                     "description": "Enunciado",
@@ -1051,6 +1751,18 @@ const docTemplate = `{
                     "description": "Ordem na lista",
                     "type": "integer"
                 },
+                "timeLimitSeconds": {
+                    "description": "Segundos para responder (0 = sem limite)",
+                    "type": "integer"
+                },
+                "tolerance": {
+                    "description": "NUMERIC: diferença máxima aceita (0 = valor exato)",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "Ver constantes Tipo*",
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
@@ -1078,6 +1790,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/quiz.Question"
                     }
                 },
+                "scoringMode": {
+                    "description": "FLAT | SPEED",
+                    "type": "string"
+                },
                 "status": {
                     "description": "DRAFT | PUBLISHED",
                     "type": "string"
@@ -1098,6 +1814,39 @@ const docTemplate = `{
                 }
             }
         },
+        "quiz.Response": {
+            "type": "object",
+            "properties": {
+                "indexes": {
+                    "description": "Alternativas marcadas",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "order": {
+                    "description": "ORDERING: índices dos itens na ordem escolhida",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pairs": {
+                    "description": "MATCHING: pares [esquerda, direita]",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "text": {
+                    "description": "Texto digitado (OPEN_TEXT e NUMERIC)",
+                    "type": "string"
+                }
+            }
+        },
         "teacher.Teacher": {
             "type": "object",
             "properties": {
@@ -1121,10 +1870,49 @@ const docTemplate = `{
         "usecases.AddQuestionInput": {
             "type": "object",
             "properties": {
+                "acceptedAnswers": {
+                    "description": "OPEN_TEXT",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "correctIndex": {
                     "type": "integer"
                 },
+                "correctIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "items": {
+                    "description": "ORDERING, na ordem correta",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matchLeft": {
+                    "description": "MATCHING",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matchRight": {
+                    "description": "MATCHING, matchRight[i] corresponde a matchLeft[i]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "numericAnswer": {
+                    "description": "NUMERIC",
+                    "type": "number"
+                },
                 "optionA": {
+                    "description": "Formato legado (4 alternativas fixas), usado quando \"options\" não é enviado",
                     "type": "string"
                 },
                 "optionB": {
@@ -1136,13 +1924,103 @@ const docTemplate = `{
                 "optionD": {
                     "type": "string"
                 },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pointsMultiplier": {
+                    "description": "1 (padrão) a 3; 2 = pontos em dobro",
+                    "type": "integer"
+                },
                 "prompt": {
                     "type": "string"
                 },
-                "quizID": {
+                "timeLimitSeconds": {
+                    "description": "0 = sem limite",
+                    "type": "integer"
+                },
+                "tolerance": {
+                    "description": "NUMERIC",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "MULTIPLE_CHOICE (padrão), TRUE_FALSE, MULTI_SELECT, OPEN_TEXT, NUMERIC, POLL, ORDERING ou MATCHING",
                     "type": "string"
+                }
+            }
+        },
+        "usecases.AnswerKey": {
+            "type": "object",
+            "properties": {
+                "correctIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "teacherID": {
+                "questionIndex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.AttemptReview": {
+            "type": "object",
+            "properties": {
+                "answerKey": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecases.AnswerKey"
+                    }
+                },
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assignment.AttemptAnswer"
+                    }
+                },
+                "assignmentId": {
+                    "type": "string"
+                },
+                "currentIndex": {
+                    "description": "Próxima pergunta a responder",
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "description": "Zero enquanto a tentativa está em andamento",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecases.CreateAssignmentInput": {
+            "type": "object",
+            "properties": {
+                "closesAt": {
+                    "description": "Prazo de entrega",
+                    "type": "string"
+                },
+                "opensAt": {
+                    "description": "Padrão: agora",
+                    "type": "string"
+                },
+                "quizId": {
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Padrão: título do quiz",
                     "type": "string"
                 }
             }
@@ -1156,6 +2034,10 @@ const docTemplate = `{
                 "grade": {
                     "type": "string"
                 },
+                "scoringMode": {
+                    "description": "FLAT (padrão) | SPEED",
+                    "type": "string"
+                },
                 "subject": {
                     "type": "string"
                 },
@@ -1167,6 +2049,76 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.HomeworkAnswerResult": {
+            "type": "object",
+            "properties": {
+                "correct": {
+                    "type": "boolean"
+                },
+                "credit": {
+                    "type": "number"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "questionIndex": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.HomeworkInfo": {
+            "type": "object",
+            "properties": {
+                "closesAt": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "opensAt": {
+                    "type": "string"
+                },
+                "quizTitle": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "SCHEDULED | OPEN | CLOSED",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "totalQuestions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecases.HomeworkQuestion": {
+            "type": "object",
+            "properties": {
+                "finished": {
+                    "type": "boolean"
+                },
+                "question": {
+                    "$ref": "#/definitions/quiz.Question"
+                },
+                "questionIndex": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "totalQuestions": {
+                    "type": "integer"
+                }
+            }
+        },
         "usecases.LoginInput": {
             "type": "object",
             "properties": {
@@ -1219,13 +2171,63 @@ const docTemplate = `{
                 }
             }
         },
+        "usecases.StartAttemptOutput": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "$ref": "#/definitions/assignment.Attempt"
+                },
+                "attemptToken": {
+                    "type": "string"
+                }
+            }
+        },
         "usecases.UpdateQuestionInput": {
             "type": "object",
             "properties": {
+                "acceptedAnswers": {
+                    "description": "OPEN_TEXT",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "correctIndex": {
                     "type": "integer"
                 },
+                "correctIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "items": {
+                    "description": "ORDERING, na ordem correta",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matchLeft": {
+                    "description": "MATCHING",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matchRight": {
+                    "description": "MATCHING, matchRight[i] corresponde a matchLeft[i]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "numericAnswer": {
+                    "description": "NUMERIC",
+                    "type": "number"
+                },
                 "optionA": {
+                    "description": "Formato legado (4 alternativas fixas), usado quando \"options\" não é enviado",
                     "type": "string"
                 },
                 "optionB": {
@@ -1237,16 +2239,29 @@ const docTemplate = `{
                 "optionD": {
                     "type": "string"
                 },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pointsMultiplier": {
+                    "description": "1 (padrão) a 3; 2 = pontos em dobro",
+                    "type": "integer"
+                },
                 "prompt": {
                     "type": "string"
                 },
-                "questionID": {
-                    "type": "string"
+                "timeLimitSeconds": {
+                    "description": "0 = sem limite",
+                    "type": "integer"
                 },
-                "quizID": {
-                    "type": "string"
+                "tolerance": {
+                    "description": "NUMERIC",
+                    "type": "number"
                 },
-                "teacherID": {
+                "type": {
+                    "description": "MULTIPLE_CHOICE (padrão), TRUE_FALSE, MULTI_SELECT, OPEN_TEXT, NUMERIC, POLL, ORDERING ou MATCHING",
                     "type": "string"
                 }
             }
@@ -1263,6 +2278,9 @@ const docTemplate = `{
                 "quizID": {
                     "type": "string"
                 },
+                "scoringMode": {
+                    "type": "string"
+                },
                 "subject": {
                     "type": "string"
                 },
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"rankit/internal/adapters/http/middlewares"
+	"rankit/internal/application/usecases"
+	"rankit/internal/domain/assignment"
+	"rankit/internal/domain/quiz"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AssignmentHandler struct {
+	assignmentUC *usecases.AssignmentUseCases
+}
+
+func NewAssignmentHandler(assignmentUC *usecases.AssignmentUseCases) *AssignmentHandler {
+	return &AssignmentHandler{assignmentUC: assignmentUC}
+}
+
+// CreateAssignment godoc
+// @Summary Passa um quiz como tarefa (lição de casa)
+// @Description Cria uma tarefa a partir de um quiz PUBLISHED. Os alunos entram pelo código e respondem no próprio ritmo até closesAt.
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body usecases.CreateAssignmentInput true "payload: {quizId: uuid, title?: string, opensAt?: RFC3339, closesAt: RFC3339}"
+// @Success 201 {object} assignment.Assignment
+// @Failure 400 "Dados inválidos"
+// @Router /assignments [post]
+func (h *AssignmentHandler) CreateAssignment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+
+	var input usecases.CreateAssignmentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+	input.TeacherID = userID
+
+	a, err := h.assignmentUC.CreateAssignment(r.Context(), input)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(a)
+}
+
+// ListAssignments godoc
+// @Summary Lista tarefas do professor
+// @Tags Assignments
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} assignment.Assignment
+// @Router /assignments [get]
+func (h *AssignmentHandler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+
+	assignments, err := h.assignmentUC.ListAssignments(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Erro ao listar tarefas", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(assignments)
+}
+
+// GetAssignment godoc
+// @Summary Detalha uma tarefa
+// @Tags Assignments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da Tarefa"
+// @Success 200 {object} assignment.Assignment
+// @Failure 404 "Tarefa não encontrada"
+// @Router /assignments/{id} [get]
+func (h *AssignmentHandler) GetAssignment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+	id := chi.URLParam(r, "id")
+
+	a, err := h.assignmentUC.GetAssignment(r.Context(), id, userID)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(a)
+}
+
+// GetAssignmentReport godoc
+// @Summary Relatório da tarefa
+// @Description Resultado das tentativas até o momento, no mesmo formato do histórico das salas.
+// @Tags Assignments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da Tarefa"
+// @Success 200 {object} history.RoomHistory
+// @Failure 404 "Tarefa não encontrada"
+// @Router /assignments/{id}/report [get]
+func (h *AssignmentHandler) GetAssignmentReport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+	id := chi.URLParam(r, "id")
+
+	report, err := h.assignmentUC.GetReport(r.Context(), id, userID)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
+
+// CloseAssignment godoc
+// @Summary Encerra e arquiva a tarefa
+// @Description Antecipa o prazo (se ainda aberta) e grava o resultado no histórico (GET /reports/rooms). O historyId fica na tarefa.
+// @Tags Assignments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da Tarefa"
+// @Success 200 {object} assignment.Assignment
+// @Failure 404 "Tarefa não encontrada"
+// @Router /assignments/{id}/close [post]
+func (h *AssignmentHandler) CloseAssignment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+	id := chi.URLParam(r, "id")
+
+	a, err := h.assignmentUC.CloseAssignment(r.Context(), id, userID)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(a)
+}
+
+// GetHomework godoc
+// @Summary Dados públicos da tarefa
+// @Tags Homework
+// @Produce json
+// @Param code path string true "Código da Tarefa"
+// @Success 200 {object} usecases.HomeworkInfo
+// @Failure 404 "Tarefa não encontrada"
+// @Router /homework/{code} [get]
+func (h *AssignmentHandler) GetHomework(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	info, err := h.assignmentUC.GetHomework(r.Context(), code)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(info)
+}
+
+// StartAttempt godoc
+// @Summary Inicia a tentativa do aluno
+// @Description Retorna o attemptToken usado nas chamadas seguintes (guardar para continuar depois).
+// @Tags Homework
+// @Accept json
+// @Produce json
+// @Param code path string true "Código da Tarefa"
+// @Param body body map[string]string true "payload: {nickname: string}"
+// @Success 201 {object} usecases.StartAttemptOutput
+// @Failure 409 "Tarefa fora do prazo ou apelido em uso"
+// @Router /homework/{code}/attempts [post]
+func (h *AssignmentHandler) StartAttempt(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	var input struct {
+		Nickname string `json:"nickname"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	out, err := h.assignmentUC.StartAttempt(r.Context(), code, input.Nickname)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+// GetAttempt godoc
+// @Summary Progresso da tentativa
+// @Description Respostas enviadas e pontuação. O gabarito (answerKey) só aparece depois que a tarefa é encerrada, pelo prazo ou pelo professor.
+// @Tags Homework
+// @Produce json
+// @Param token path string true "attemptToken"
+// @Success 200 {object} usecases.AttemptReview
+// @Failure 404 "Tentativa não encontrada"
+// @Router /homework/attempts/{token} [get]
+func (h *AssignmentHandler) GetAttempt(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	t, err := h.assignmentUC.GetAttempt(r.Context(), token)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(t)
+}
+
+// GetAttemptQuestion godoc
+// @Summary Próxima pergunta da tentativa
+// @Description Retorna a pergunta atual sem o gabarito (question ausente quando a tentativa terminou).
+// @Tags Homework
+// @Produce json
+// @Param token path string true "attemptToken"
+// @Success 200 {object} usecases.HomeworkQuestion
+// @Failure 409 "Tarefa fora do prazo"
+// @Router /homework/attempts/{token}/question [get]
+func (h *AssignmentHandler) GetAttemptQuestion(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	out, err := h.assignmentUC.NextQuestion(r.Context(), token)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(out)
+}
+
+// SubmitAttemptAnswer godoc
+// @Summary Responde a pergunta atual
+// @Description Os campos de resposta seguem o evento submit_answer do WebSocket (posições exibidas em ORDERING/MATCHING). A correção informa acerto e pontos, mas não o gabarito: ele só é liberado em GET /homework/attempts/{token} após o encerramento da tarefa.
+// @Tags Homework
+// @Accept json
+// @Produce json
+// @Param token path string true "attemptToken"
+// @Param body body map[string]interface{} true "payload: {questionIndex: int, answerIndex?: int, answerIndexes?: [int], answerText?: string, answerOrder?: [int], answerPairs?: [[int,int]]}"
+// @Success 200 {object} usecases.HomeworkAnswerResult
+// @Failure 400 "Resposta inválida"
+// @Failure 409 "Pergunta fora de ordem ou tarefa fora do prazo"
+// @Router /homework/attempts/{token}/answers [post]
+func (h *AssignmentHandler) SubmitAttemptAnswer(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	var input struct {
+		QuestionIndex *int     `json:"questionIndex"`
+		AnswerIndex   *int     `json:"answerIndex"`
+		AnswerIndexes []int    `json:"answerIndexes"`
+		AnswerText    string   `json:"answerText"`
+		AnswerOrder   []int    `json:"answerOrder"`
+		AnswerPairs   [][2]int `json:"answerPairs"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+	if input.QuestionIndex == nil {
+		http.Error(w, "questionIndex é obrigatório", http.StatusBadRequest)
+		return
+	}
+
+	resp := quiz.Response{
+		Indexes: input.AnswerIndexes,
+		Text:    input.AnswerText,
+		Order:   input.AnswerOrder,
+		Pairs:   input.AnswerPairs,
+	}
+	if resp.Indexes == nil && input.AnswerIndex != nil {
+		resp.Indexes = []int{*input.AnswerIndex}
+	}
+
+	out, err := h.assignmentUC.SubmitAnswer(r.Context(), token, *input.QuestionIndex, resp)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(out)
+}
+
+// writeAssignmentError traduz os erros de tarefa para o status HTTP.
+func writeAssignmentError(w http.ResponseWriter, err error) {
+	switch err {
+	case usecases.ErrTarefaNaoEncontrada, usecases.ErrTentativaNaoEncontrada,
+		usecases.ErrQuizNaoEncontrado, usecases.ErrNaoAutorizado:
+		http.Error(w, err.Error(), http.StatusNotFound) // 404 para não vazar
+	case assignment.ErrQuizNaoPublicado, assignment.ErrPrazoObrigatorio, assignment.ErrPeriodoInvalido,
+		assignment.ErrApelidoObrigatorio, quiz.ErrRespostaInvalida:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case assignment.ErrTarefaNaoIniciada, assignment.ErrTarefaEncerrada, assignment.ErrTentativaConcluida,
+		assignment.ErrPerguntaForaDeOrdem, usecases.ErrApelidoEmUsoTarefa:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
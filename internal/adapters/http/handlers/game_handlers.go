@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"rankit/internal/adapters/http/middlewares"
 	"rankit/internal/application/usecases"
+	"rankit/internal/domain/game"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -19,13 +20,13 @@ func NewGameHandler(gameUC *usecases.GameUseCases) *GameHandler {
 
 // CreateRoom godoc
 // @Summary Cria uma sala de jogo
-// @Description Cria uma nova sala a partir de um quiz PUBLISHED.
+// @Description Cria uma nova sala a partir de um quiz PUBLISHED. Opcionalmente habilita o modo times, embaralha perguntas e alternativas e define as regras de entrada (entrada tardia, limite de alunos e aprovação automática).
 // @Tags Rooms
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param body body map[string]string true "payload: {quizId: uuid}"
-// @Success 201 {object} game.Room
+// @Param body body map[string]interface{} true "payload: {quizId: uuid, teamMode?: bool, teamScoring?: SUM|AVERAGE, autoBalanceTeams?: bool, teams?: [string], shuffleQuestions?: bool, shuffleOptions?: bool, lateJoin?: DENY|ZERO|AVERAGE, maxPlayers?: int, autoApprove?: bool, answerPolicy?: CHANGEABLE|FIRST_FINAL}"
+// @Success 201 {object} game.PublicRoom
 // @Failure 400 "Quiz inválido"
 // @Router /rooms [post]
 func (h *GameHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
@@ -33,28 +34,30 @@ func (h *GameHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 
 	var input struct {
 		QuizID string `json:"quizId"`
+		game.RoomSettings
 	}
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "JSON inválido", http.StatusBadRequest)
 		return
 	}
 
-	room, err := h.gameUC.CreateRoom(r.Context(), userID, input.QuizID)
+	room, err := h.gameUC.CreateRoom(r.Context(), userID, input.QuizID, input.RoomSettings)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(room)
+	json.NewEncoder(w).Encode(room.GetPublicInfo())
 }
 
 // GetRoom godoc
 // @Summary Obtém dados da sala
+// @Description Aceita o ID interno ou o código numérico de entrada da sala. Retorna apenas dados públicos: a pergunta atual sem gabarito e os alunos pelo apelido, sem as respostas.
 // @Tags Rooms
 // @Produce json
-// @Param id path string true "Room ID"
-// @Success 200 {object} game.Room
+// @Param id path string true "Room ID ou código"
+// @Success 200 {object} game.PublicRoom
 // @Failure 404 "Sala não encontrada"
 // @Router /rooms/{id} [get]
 func (h *GameHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
@@ -72,3 +75,56 @@ func (h *GameHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(room)
 }
+
+// CloseRoom godoc
+// @Summary Encerra uma sala de jogo
+// @Description Arquiva a sala (como ABANDONED se o quiz não terminou), desconecta os participantes e remove a sala.
+// @Tags Rooms
+// @Security BearerAuth
+// @Param id path string true "Room ID"
+// @Success 204 "No Content"
+// @Failure 404 "Sala não encontrada"
+// @Router /rooms/{id} [delete]
+func (h *GameHandler) CloseRoom(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+	roomID := chi.URLParam(r, "id")
+
+	if err := h.gameUC.CloseRoom(r.Context(), roomID, userID); err != nil {
+		if err == usecases.ErrSalaNaoEncontrada || err == usecases.ErrNaoAutorizado {
+			http.Error(w, "Sala não encontrada", http.StatusNotFound) // 404 para não vazar
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueDisplayCode godoc
+// @Summary Gera o código da tela de projeção
+// @Description Autoriza um projetor a acompanhar a sala via WebSocket (/ws?roomId=...&display=<código>). A tela não entra como aluno nem pode responder. Gerar um novo código invalida o anterior.
+// @Tags Rooms
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Room ID"
+// @Success 201 {object} map[string]string "displayCode"
+// @Failure 404 "Sala não encontrada"
+// @Router /rooms/{id}/display-code [post]
+func (h *GameHandler) IssueDisplayCode(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(middlewares.UserIDKey).(string)
+	roomID := chi.URLParam(r, "id")
+
+	code, err := h.gameUC.IssueDisplayCode(roomID, userID)
+	if err != nil {
+		if err == usecases.ErrSalaNaoEncontrada || err == usecases.ErrNaoAutorizado {
+			http.Error(w, "Sala não encontrada", http.StatusNotFound) // 404 para não vazar
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"displayCode": code})
+}
@@ -44,7 +44,7 @@ func (h *QuestionHandler) AddQuestion(w http.ResponseWriter, r *http.Request) {
 
 	q, err := h.questionUC.AddQuestion(r.Context(), input)
 	if err != nil {
-		if err == quiz.ErrEnunciadoObrigatorio || err == quiz.ErrAlternativaVazia || err == quiz.ErrIndiceInvalido {
+		if isQuestionValidationError(err) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -87,7 +87,10 @@ func (h *QuestionHandler) UpdateQuestion(w http.ResponseWriter, r *http.Request)
 
 	q, err := h.questionUC.UpdateQuestion(r.Context(), input)
 	if err != nil {
-		// Mesmos erros...
+		if isQuestionValidationError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -144,3 +147,16 @@ func (h *QuestionHandler) ReorderQuestions(w http.ResponseWriter, r *http.Reques
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// isQuestionValidationError indica se o erro vem da validação da pergunta (400).
+func isQuestionValidationError(err error) bool {
+	switch err {
+	case quiz.ErrEnunciadoObrigatorio, quiz.ErrTipoPerguntaInvalido, quiz.ErrQuantidadeOpcoes,
+		quiz.ErrVerdadeiroFalso, quiz.ErrAlternativaVazia, quiz.ErrIndiceInvalido,
+		quiz.ErrRespostaCorretaUnica, quiz.ErrRespostasAceitas, quiz.ErrRespostaNumerica,
+		quiz.ErrToleranciaInvalida, quiz.ErrQuantidadeItens, quiz.ErrItemVazio,
+		quiz.ErrColunasAssociacao, quiz.ErrTempoLimiteInvalido, quiz.ErrMultiplicadorPontos:
+		return true
+	}
+	return false
+}
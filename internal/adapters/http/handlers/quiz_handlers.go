@@ -43,7 +43,7 @@ func (h *QuizHandler) CreateQuiz(w http.ResponseWriter, r *http.Request) {
 
 	q, err := h.quizUC.CreateQuiz(r.Context(), input)
 	if err != nil {
-		if err == quiz.ErrTituloObrigatorio {
+		if err == quiz.ErrTituloObrigatorio || err == quiz.ErrModoPontuacaoInvalido {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -132,7 +132,7 @@ func (h *QuizHandler) UpdateQuiz(w http.ResponseWriter, r *http.Request) {
 
 	q, err := h.quizUC.UpdateQuiz(r.Context(), input)
 	if err != nil {
-		if err == quiz.ErrQuizPublicadoNaoEdita {
+		if err == quiz.ErrQuizPublicadoNaoEdita || err == quiz.ErrTituloObrigatorio || err == quiz.ErrModoPontuacaoInvalido {
 			http.Error(w, err.Error(), http.StatusBadRequest) // ou 409
 			return
 		}
@@ -23,6 +23,7 @@ func NewRouter(
 	questionHandler *handlers.QuestionHandler,
 	gameHandler *handlers.GameHandler,
 	reportHandler *handlers.ReportHandler,
+	assignmentHandler *handlers.AssignmentHandler,
 	wsHandler *websocket.WebSocketHandler,
 	tokenService ports.TokenService,
 ) http.Handler {
@@ -95,6 +96,8 @@ func NewRouter(
 		r.Group(func(r chi.Router) {
 			r.Use(middlewares.AuthMiddleware(tokenService))
 			r.Post("/", gameHandler.CreateRoom)
+			r.Delete("/{id}", gameHandler.CloseRoom)
+			r.Post("/{id}/display-code", gameHandler.IssueDisplayCode)
 		})
 
 		// Visualizar detalhes da sala pode ser público (para alunos confirmarem info)
@@ -112,5 +115,26 @@ func NewRouter(
 		r.Get("/quizzes/{id}", reportHandler.GetQuizStats)
 	})
 
+	// Grupo de rotas de Tarefas / lição de casa (Protegidas)
+	r.Route("/assignments", func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware(tokenService))
+
+		r.Post("/", assignmentHandler.CreateAssignment)
+		r.Get("/", assignmentHandler.ListAssignments)
+		r.Get("/{id}", assignmentHandler.GetAssignment)
+		r.Get("/{id}/report", assignmentHandler.GetAssignmentReport)
+		r.Post("/{id}/close", assignmentHandler.CloseAssignment)
+	})
+
+	// Lição de casa do lado do aluno (pública: código da tarefa e token da tentativa)
+	r.Route("/homework", func(r chi.Router) {
+		r.Get("/{code}", assignmentHandler.GetHomework)
+		r.Post("/{code}/attempts", assignmentHandler.StartAttempt)
+
+		r.Get("/attempts/{token}", assignmentHandler.GetAttempt)
+		r.Get("/attempts/{token}/question", assignmentHandler.GetAttemptQuestion)
+		r.Post("/attempts/{token}/answers", assignmentHandler.SubmitAttemptAnswer)
+	})
+
 	return r
 }
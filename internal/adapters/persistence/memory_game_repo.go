@@ -16,11 +16,16 @@ func NewInMemoryGameRepository() ports.GameRepository {
 	return &InMemoryGameRepository{}
 }
 
-func (r *InMemoryGameRepository) SaveRoom(room *game.Room) error {
+func (r *InMemoryGameRepository) AddRoom(room *game.Room) error {
 	r.rooms.Store(room.ID, room)
 	return nil
 }
 
+// SaveRoom não tem o que gravar: a sala registrada já é o próprio estado em memória.
+func (r *InMemoryGameRepository) SaveRoom(room *game.Room) error {
+	return nil
+}
+
 func (r *InMemoryGameRepository) FindRoomByID(id string) (*game.Room, error) {
 	val, ok := r.rooms.Load(id)
 	if !ok {
@@ -34,7 +39,30 @@ func (r *InMemoryGameRepository) FindRoomByID(id string) (*game.Room, error) {
 	return room, nil
 }
 
+func (r *InMemoryGameRepository) FindRoomByCode(code string) (*game.Room, error) {
+	var found *game.Room
+	r.rooms.Range(func(_, val any) bool {
+		if room, ok := val.(*game.Room); ok && room.Code == code {
+			found = room
+			return false
+		}
+		return true
+	})
+	return found, nil
+}
+
 func (r *InMemoryGameRepository) DeleteRoom(id string) error {
 	r.rooms.Delete(id)
 	return nil
 }
+
+func (r *InMemoryGameRepository) ListRooms() ([]*game.Room, error) {
+	var rooms []*game.Room
+	r.rooms.Range(func(_, val any) bool {
+		if room, ok := val.(*game.Room); ok {
+			rooms = append(rooms, room)
+		}
+		return true
+	})
+	return rooms, nil
+}
@@ -0,0 +1,294 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"rankit/internal/domain/assignment"
+)
+
+type SQLiteAssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteAssignmentRepository(db *sql.DB) *SQLiteAssignmentRepository {
+	return &SQLiteAssignmentRepository{db: db}
+}
+
+// ------ ASSIGNMENT METHODS ------
+
+func (r *SQLiteAssignmentRepository) Save(ctx context.Context, a *assignment.Assignment) error {
+	query := `
+		INSERT INTO assignments (id, teacher_id, quiz_id, title, code, opens_at, closes_at, history_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		a.ID, a.TeacherID, a.QuizID, a.Title, a.Code, a.OpensAt, a.ClosesAt, a.HistoryID, a.CreatedAt,
+	)
+	return err
+}
+
+func (r *SQLiteAssignmentRepository) Update(ctx context.Context, a *assignment.Assignment) error {
+	query := `
+		UPDATE assignments
+		SET title = ?, opens_at = ?, closes_at = ?, history_id = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, a.Title, a.OpensAt, a.ClosesAt, a.HistoryID, a.ID)
+	return err
+}
+
+const selectAssignment = `
+	SELECT id, teacher_id, quiz_id, title, code, opens_at, closes_at, history_id, created_at
+	FROM assignments
+`
+
+func (r *SQLiteAssignmentRepository) FindByID(ctx context.Context, id string) (*assignment.Assignment, error) {
+	return r.findOne(ctx, selectAssignment+" WHERE id = ?", id)
+}
+
+func (r *SQLiteAssignmentRepository) FindByCode(ctx context.Context, code string) (*assignment.Assignment, error) {
+	return r.findOne(ctx, selectAssignment+" WHERE code = ?", code)
+}
+
+func (r *SQLiteAssignmentRepository) FindByTeacherID(ctx context.Context, teacherID string) ([]*assignment.Assignment, error) {
+	rows, err := r.db.QueryContext(ctx, selectAssignment+" WHERE teacher_id = ? ORDER BY created_at DESC", teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []*assignment.Assignment
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, rows.Err()
+}
+
+func (r *SQLiteAssignmentRepository) FindUnarchived(ctx context.Context) ([]*assignment.Assignment, error) {
+	rows, err := r.db.QueryContext(ctx, selectAssignment+" WHERE history_id = '' ORDER BY closes_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []*assignment.Assignment
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, rows.Err()
+}
+
+// ClaimArchive usa um UPDATE condicional: só a primeira chamada encontra history_id vazio.
+func (r *SQLiteAssignmentRepository) ClaimArchive(ctx context.Context, a *assignment.Assignment) (bool, error) {
+	query := `
+		UPDATE assignments
+		SET opens_at = ?, closes_at = ?, history_id = ?
+		WHERE id = ? AND history_id = ''
+	`
+	res, err := r.db.ExecContext(ctx, query, a.OpensAt, a.ClosesAt, a.HistoryID, a.ID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *SQLiteAssignmentRepository) ReleaseArchive(ctx context.Context, id, historyID string) error {
+	query := `UPDATE assignments SET history_id = '' WHERE id = ? AND history_id = ?`
+	_, err := r.db.ExecContext(ctx, query, id, historyID)
+	return err
+}
+
+func (r *SQLiteAssignmentRepository) findOne(ctx context.Context, query string, arg string) (*assignment.Assignment, error) {
+	a, err := scanAssignment(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// ------ ATTEMPT METHODS ------
+
+func (r *SQLiteAssignmentRepository) SaveAttempt(ctx context.Context, t *assignment.Attempt) error {
+	query := `
+		INSERT INTO assignment_attempts (id, assignment_id, nickname, token, current_index, score, shuffle, started_at, finished_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		t.ID, t.AssignmentID, t.Nickname, t.Token, t.CurrentIndex, t.Score, toJson(t.Shuffle), t.StartedAt, attemptFinishedAt(t),
+	)
+	return err
+}
+
+const selectAttempt = `
+	SELECT id, assignment_id, nickname, token, current_index, score, shuffle, started_at, finished_at
+	FROM assignment_attempts
+`
+
+func (r *SQLiteAssignmentRepository) FindAttemptByToken(ctx context.Context, token string) (*assignment.Attempt, error) {
+	t, err := scanAttempt(r.db.QueryRowContext(ctx, selectAttempt+" WHERE token = ?", token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	answers, err := r.findAnswers(ctx, "WHERE attempt_id = ?", t.ID)
+	if err != nil {
+		return nil, err
+	}
+	t.Answers = answers[t.ID]
+	return t, nil
+}
+
+func (r *SQLiteAssignmentRepository) FindAttemptsByAssignmentID(ctx context.Context, assignmentID string) ([]*assignment.Attempt, error) {
+	rows, err := r.db.QueryContext(ctx, selectAttempt+" WHERE assignment_id = ? ORDER BY started_at", assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*assignment.Attempt
+	for rows.Next() {
+		t, err := scanAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Respostas de todas as tentativas em uma única consulta
+	answers, err := r.findAnswers(ctx,
+		"WHERE attempt_id IN (SELECT id FROM assignment_attempts WHERE assignment_id = ?)", assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range attempts {
+		t.Answers = answers[t.ID]
+	}
+	return attempts, nil
+}
+
+func (r *SQLiteAssignmentRepository) SaveAnswer(ctx context.Context, t *assignment.Attempt, ans *assignment.AttemptAnswer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queryAnswer := `
+		INSERT INTO assignment_answers (id, attempt_id, question_index, selected_indexes, answer_text, answer_order, answer_pairs, credit, is_correct, points, answered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, queryAnswer,
+		ans.ID, t.ID, ans.QuestionIndex,
+		toJson(ans.Response.Indexes), ans.Response.Text, toJson(ans.Response.Order), toJson(ans.Response.Pairs),
+		ans.Credit, ans.Correct, ans.Points, ans.AnsweredAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	queryAttempt := `
+		UPDATE assignment_attempts
+		SET current_index = ?, score = ?, shuffle = ?, finished_at = ?
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, queryAttempt, t.CurrentIndex, t.Score, toJson(t.Shuffle), attemptFinishedAt(t), t.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// findAnswers carrega as respostas que atendem ao filtro, agrupadas por tentativa.
+func (r *SQLiteAssignmentRepository) findAnswers(ctx context.Context, where string, arg string) (map[string][]assignment.AttemptAnswer, error) {
+	query := `
+		SELECT id, attempt_id, question_index, selected_indexes, answer_text, answer_order, answer_pairs, credit, is_correct, points, answered_at
+		FROM assignment_answers
+	` + where + " ORDER BY question_index"
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := make(map[string][]assignment.AttemptAnswer)
+	for rows.Next() {
+		var a assignment.AttemptAnswer
+		var selected, order, pairs string
+		if err := rows.Scan(
+			&a.ID, &a.AttemptID, &a.QuestionIndex, &selected, &a.Response.Text, &order, &pairs,
+			&a.Credit, &a.Correct, &a.Points, &a.AnsweredAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(selected), &a.Response.Indexes); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(order), &a.Response.Order); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(pairs), &a.Response.Pairs); err != nil {
+			return nil, err
+		}
+		answers[a.AttemptID] = append(answers[a.AttemptID], a)
+	}
+	return answers, rows.Err()
+}
+
+// rowScanner cobre *sql.Row e *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAssignment(row rowScanner) (*assignment.Assignment, error) {
+	var a assignment.Assignment
+	if err := row.Scan(
+		&a.ID, &a.TeacherID, &a.QuizID, &a.Title, &a.Code, &a.OpensAt, &a.ClosesAt, &a.HistoryID, &a.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func scanAttempt(row rowScanner) (*assignment.Attempt, error) {
+	var t assignment.Attempt
+	var shuffle string
+	var finishedAt sql.NullTime
+	if err := row.Scan(
+		&t.ID, &t.AssignmentID, &t.Nickname, &t.Token, &t.CurrentIndex, &t.Score, &shuffle, &t.StartedAt, &finishedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(shuffle), &t.Shuffle); err != nil {
+		return nil, err
+	}
+	t.FinishedAt = finishedAt.Time
+	return &t, nil
+}
+
+// attemptFinishedAt grava o fim da tentativa como NULL enquanto ela está em andamento.
+func attemptFinishedAt(t *assignment.Attempt) sql.NullTime {
+	return sql.NullTime{Time: t.FinishedAt, Valid: t.Finished()}
+}
@@ -0,0 +1,135 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"rankit/internal/domain/game"
+	"sync"
+	"time"
+)
+
+// SQLiteGameRepository implementa GameRepository mantendo as salas vivas em memória
+// e gravando um snapshot de cada uma no SQLite a cada alteração de estado.
+type SQLiteGameRepository struct {
+	db    *sql.DB
+	rooms sync.Map // Map[string]*game.Room (salas ativas)
+}
+
+func NewSQLiteGameRepository(db *sql.DB) *SQLiteGameRepository {
+	return &SQLiteGameRepository{db: db}
+}
+
+// AddRoom coloca a sala recém-criada entre as ativas e grava o primeiro snapshot.
+func (r *SQLiteGameRepository) AddRoom(room *game.Room) error {
+	snapshot := room.Snapshot()
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO live_rooms (id, teacher_id, status, version, snapshot, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	if _, err := r.db.Exec(query,
+		snapshot.ID, snapshot.TeacherID, snapshot.Status, snapshot.Version, string(data), time.Now(),
+	); err != nil {
+		return err
+	}
+	r.rooms.Store(room.ID, room)
+	return nil
+}
+
+// SaveRoom atualiza o snapshot da sala. Snapshots mais antigos que o já salvo são ignorados, e o
+// UPDATE nunca recria a linha: uma gravação que perde a corrida para DeleteRoom não ressuscita a sala.
+func (r *SQLiteGameRepository) SaveRoom(room *game.Room) error {
+	if room.Closed() {
+		return nil
+	}
+
+	snapshot := room.Snapshot()
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE live_rooms SET status = ?, version = ?, snapshot = ?, updated_at = ?
+		WHERE id = ? AND version <= ?
+	`
+	_, err = r.db.Exec(query,
+		snapshot.Status, snapshot.Version, string(data), time.Now(), snapshot.ID, snapshot.Version,
+	)
+	return err
+}
+
+func (r *SQLiteGameRepository) FindRoomByID(id string) (*game.Room, error) {
+	val, ok := r.rooms.Load(id)
+	if !ok {
+		return nil, nil // Não encontrado (sem erro)
+	}
+
+	room, ok := val.(*game.Room)
+	if !ok {
+		return nil, errors.New("erro de tipo no repositório de jogos")
+	}
+	return room, nil
+}
+
+func (r *SQLiteGameRepository) FindRoomByCode(code string) (*game.Room, error) {
+	var found *game.Room
+	r.rooms.Range(func(_, val any) bool {
+		if room, ok := val.(*game.Room); ok && room.Code == code {
+			found = room
+			return false
+		}
+		return true
+	})
+	return found, nil
+}
+
+func (r *SQLiteGameRepository) DeleteRoom(id string) error {
+	r.rooms.Delete(id)
+	_, err := r.db.Exec("DELETE FROM live_rooms WHERE id = ?", id)
+	return err
+}
+
+func (r *SQLiteGameRepository) ListRooms() ([]*game.Room, error) {
+	var rooms []*game.Room
+	r.rooms.Range(func(_, val any) bool {
+		if room, ok := val.(*game.Room); ok {
+			rooms = append(rooms, room)
+		}
+		return true
+	})
+	return rooms, nil
+}
+
+// LoadActiveRooms restaura para a memória todas as salas salvas (chamado na inicialização).
+func (r *SQLiteGameRepository) LoadActiveRooms(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT snapshot FROM live_rooms")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return count, err
+		}
+
+		var snapshot game.RoomSnapshot
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return count, err
+		}
+
+		room := game.RestoreRoom(snapshot)
+		r.rooms.Store(room.ID, room)
+		count++
+	}
+	return count, rows.Err()
+}
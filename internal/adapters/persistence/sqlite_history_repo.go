@@ -26,25 +26,39 @@ func (r *SQLiteHistoryRepository) SaveHistory(ctx context.Context, h *history.Ro
 
 	// 1. Save Room History
 	queryRoom := `
-		INSERT INTO rooms_history (id, room_id, teacher_id, quiz_id, quiz_title_snapshot, status, total_questions, started_at, finished_at, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO rooms_history (id, room_id, teacher_id, quiz_id, quiz_title_snapshot, status, total_questions, started_at, finished_at, created_at, team_scoring)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = tx.ExecContext(ctx, queryRoom,
 		h.ID, h.RoomID, h.TeacherID, h.QuizID, h.QuizTitleSnapshot,
-		h.Status, h.TotalQuestions, h.StartedAt, h.FinishedAt, h.CreatedAt,
+		h.Status, h.TotalQuestions, h.StartedAt, h.FinishedAt, h.CreatedAt, h.TeamScoring,
 	)
 	if err != nil {
 		return err
 	}
 
+	// 1b. Save Room Teams (modo times)
+	queryTeam := `
+		INSERT INTO room_teams (id, room_history_id, team_runtime_id, name, score, member_count, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, t := range h.Teams {
+		_, err = tx.ExecContext(ctx, queryTeam,
+			t.ID, h.ID, t.TeamRuntimeID, t.Name, t.Score, t.MemberCount, time.Now(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// 2. Save Room Players
 	queryPlayer := `
-		INSERT INTO room_players (id, room_history_id, player_runtime_id, nickname, score, correct_count, wrong_count, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO room_players (id, room_history_id, player_runtime_id, nickname, score, correct_count, wrong_count, team_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	for _, p := range h.Players {
 		_, err = tx.ExecContext(ctx, queryPlayer,
-			p.ID, h.ID, p.PlayerRuntimeID, p.Nickname, p.Score, p.CorrectCount, p.WrongCount, time.Now(),
+			p.ID, h.ID, p.PlayerRuntimeID, p.Nickname, p.Score, p.CorrectCount, p.WrongCount, p.TeamID, time.Now(),
 		)
 		if err != nil {
 			return err
@@ -52,14 +66,18 @@ func (r *SQLiteHistoryRepository) SaveHistory(ctx context.Context, h *history.Ro
 	}
 
 	// 3. Save Room Questions
+	// correct_index e count_a..count_d são colunas legadas (primeira correta / 4 primeiras alternativas)
 	queryQuestion := `
-		INSERT INTO room_questions (id, room_history_id, question_index, question_id, prompt_snapshot, correct_index, count_a, count_b, count_c, count_d, correct_count, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO room_questions (id, room_history_id, question_index, question_id, prompt_snapshot, question_type, correct_indexes, option_counts, correct_index, count_a, count_b, count_c, count_d, correct_count, skipped, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	for _, q := range h.Questions {
 		_, err = tx.ExecContext(ctx, queryQuestion,
-			q.ID, h.ID, q.QuestionIndex, q.QuestionID, q.PromptSnapshot, q.CorrectIndex,
-			q.CountA, q.CountB, q.CountC, q.CountD, q.CorrectCount, time.Now(),
+			q.ID, h.ID, q.QuestionIndex, q.QuestionID, q.PromptSnapshot,
+			q.QuestionType, toJson(q.CorrectIndexes), toJson(q.OptionCounts),
+			firstOr(q.CorrectIndexes, -1),
+			countAt(q.OptionCounts, 0), countAt(q.OptionCounts, 1), countAt(q.OptionCounts, 2), countAt(q.OptionCounts, 3),
+			q.CorrectCount, q.Skipped, time.Now(),
 		)
 		if err != nil {
 			return err
@@ -68,12 +86,15 @@ func (r *SQLiteHistoryRepository) SaveHistory(ctx context.Context, h *history.Ro
 
 	// 4. Save Room Answers
 	queryAnswer := `
-		INSERT INTO room_answers (id, room_history_id, question_index, room_player_id, selected_index, is_correct, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO room_answers (id, room_history_id, question_index, room_player_id, selected_indexes, selected_index, answer_text, answer_order, answer_pairs, is_correct, change_count, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	for _, a := range h.Answers {
 		_, err = tx.ExecContext(ctx, queryAnswer,
-			a.ID, h.ID, a.QuestionIndex, a.RoomPlayerID, a.SelectedIndex, a.IsCorrect, time.Now(),
+			a.ID, h.ID, a.QuestionIndex, a.RoomPlayerID,
+			toJson(a.SelectedIndexes), firstOr(a.SelectedIndexes, -1), a.AnswerText,
+			toJson(a.Order), toJson(a.Pairs),
+			a.IsCorrect, a.ChangeCount, time.Now(),
 		)
 		if err != nil {
 			return err
@@ -115,7 +136,7 @@ func (r *SQLiteHistoryRepository) ListByTeacherID(ctx context.Context, teacherID
 // GetByID busca histórico detalhado.
 func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*history.RoomHistory, error) {
 	query := `
-		SELECT id, room_id, teacher_id, quiz_id, quiz_title_snapshot, status, total_questions, started_at, finished_at, created_at
+		SELECT id, room_id, teacher_id, quiz_id, quiz_title_snapshot, status, total_questions, started_at, finished_at, created_at, team_scoring
 		FROM rooms_history
 		WHERE id = ?
 	`
@@ -124,13 +145,29 @@ func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*hist
 	var h history.RoomHistory
 	if err := row.Scan(
 		&h.ID, &h.RoomID, &h.TeacherID, &h.QuizID, &h.QuizTitleSnapshot,
-		&h.Status, &h.TotalQuestions, &h.StartedAt, &h.FinishedAt, &h.CreatedAt,
+		&h.Status, &h.TotalQuestions, &h.StartedAt, &h.FinishedAt, &h.CreatedAt, &h.TeamScoring,
 	); err != nil {
 		return nil, err
 	}
 
+	// Carrega Times
+	tRows, err := r.db.QueryContext(ctx, "SELECT id, team_runtime_id, name, score, member_count FROM room_teams WHERE room_history_id = ? ORDER BY score DESC", h.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer tRows.Close()
+
+	for tRows.Next() {
+		var t history.TeamStats
+		t.RoomHistoryID = h.ID
+		if err := tRows.Scan(&t.ID, &t.TeamRuntimeID, &t.Name, &t.Score, &t.MemberCount); err != nil {
+			return nil, err
+		}
+		h.Teams = append(h.Teams, t)
+	}
+
 	// Carrega Players
-	pRows, err := r.db.QueryContext(ctx, "SELECT id, player_runtime_id, nickname, score, correct_count, wrong_count FROM room_players WHERE room_history_id = ?", h.ID)
+	pRows, err := r.db.QueryContext(ctx, "SELECT id, player_runtime_id, nickname, score, correct_count, wrong_count, team_id FROM room_players WHERE room_history_id = ?", h.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,14 +176,14 @@ func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*hist
 	for pRows.Next() {
 		var p history.PlayerStats
 		p.RoomHistoryID = h.ID
-		if err := pRows.Scan(&p.ID, &p.PlayerRuntimeID, &p.Nickname, &p.Score, &p.CorrectCount, &p.WrongCount); err != nil {
+		if err := pRows.Scan(&p.ID, &p.PlayerRuntimeID, &p.Nickname, &p.Score, &p.CorrectCount, &p.WrongCount, &p.TeamID); err != nil {
 			return nil, err
 		}
 		h.Players = append(h.Players, p)
 	}
 
 	// Carrega Questions Stats
-	qRows, err := r.db.QueryContext(ctx, "SELECT id, question_index, question_id, correct_index, count_a, count_b, count_c, count_d, correct_count FROM room_questions WHERE room_history_id = ?", h.ID)
+	qRows, err := r.db.QueryContext(ctx, "SELECT id, question_index, question_id, prompt_snapshot, question_type, correct_indexes, option_counts, correct_count, skipped FROM room_questions WHERE room_history_id = ? ORDER BY question_index", h.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -155,12 +192,48 @@ func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*hist
 	for qRows.Next() {
 		var q history.QuestionStats
 		q.RoomHistoryID = h.ID
-		if err := qRows.Scan(&q.ID, &q.QuestionIndex, &q.QuestionID, &q.CorrectIndex, &q.CountA, &q.CountB, &q.CountC, &q.CountD, &q.CorrectCount); err != nil {
+		var questionID, prompt sql.NullString
+		var correctIndexes, optionCounts string
+		if err := qRows.Scan(&q.ID, &q.QuestionIndex, &questionID, &prompt, &q.QuestionType, &correctIndexes, &optionCounts, &q.CorrectCount, &q.Skipped); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(correctIndexes), &q.CorrectIndexes); err != nil {
 			return nil, err
 		}
+		if err := json.Unmarshal([]byte(optionCounts), &q.OptionCounts); err != nil {
+			return nil, err
+		}
+		q.QuestionID = questionID.String
+		q.PromptSnapshot = prompt.String
 		h.Questions = append(h.Questions, q)
 	}
 
+	// Carrega Respostas individuais
+	aRows, err := r.db.QueryContext(ctx, "SELECT id, question_index, room_player_id, selected_indexes, answer_text, answer_order, answer_pairs, is_correct, change_count FROM room_answers WHERE room_history_id = ? ORDER BY question_index", h.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer aRows.Close()
+
+	for aRows.Next() {
+		var a history.PlayerAnswer
+		a.RoomHistoryID = h.ID
+		var selected, order, pairs string
+		if err := aRows.Scan(&a.ID, &a.QuestionIndex, &a.RoomPlayerID, &selected, &a.AnswerText, &order, &pairs, &a.IsCorrect, &a.ChangeCount); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(selected), &a.SelectedIndexes); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(order), &a.Order); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(pairs), &a.Pairs); err != nil {
+			return nil, err
+		}
+		h.Answers = append(h.Answers, a)
+	}
+
 	return &h, nil
 }
 
@@ -215,3 +288,19 @@ func toJson(v interface{}) string {
 	b, _ := json.Marshal(v)
 	return string(b)
 }
+
+// firstOr retorna o primeiro elemento da lista ou `def` se ela estiver vazia.
+func firstOr(values []int, def int) int {
+	if len(values) == 0 {
+		return def
+	}
+	return values[0]
+}
+
+// countAt retorna o contador da alternativa `i` (0 se a pergunta tem menos alternativas).
+func countAt(counts []int, i int) int {
+	if i < len(counts) {
+		return counts[i]
+	}
+	return 0
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"rankit/internal/domain/quiz"
 )
 
@@ -15,18 +16,7 @@ func NewSQLiteQuestionRepository(db *sql.DB) *SQLiteQuestionRepository {
 }
 
 func (r *SQLiteQuestionRepository) Save(ctx context.Context, q *quiz.Question) error {
-	// Chama lógica reutilizável ou implementa direto
-	query := `
-		INSERT INTO questions (id, quiz_id, prompt, option_a, option_b, option_c, option_d, correct_index, sort_order, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := r.db.ExecContext(ctx, query,
-		q.ID, q.QuizID, q.Prompt,
-		q.OptionA, q.OptionB, q.OptionC, q.OptionD,
-		q.CorrectIndex, q.SortOrder,
-		q.CreatedAt, q.UpdatedAt,
-	)
-	return err
+	return insertQuestion(ctx, r.db, q)
 }
 
 func (r *SQLiteQuestionRepository) Delete(ctx context.Context, id string) error {
@@ -35,32 +25,7 @@ func (r *SQLiteQuestionRepository) Delete(ctx context.Context, id string) error
 }
 
 func (r *SQLiteQuestionRepository) FindByQuizID(ctx context.Context, quizID string) ([]*quiz.Question, error) {
-	query := `
-		SELECT id, quiz_id, prompt, option_a, option_b, option_c, option_d, correct_index, sort_order, created_at, updated_at
-		FROM questions
-		WHERE quiz_id = ?
-		ORDER BY sort_order ASC
-	`
-	rows, err := r.db.QueryContext(ctx, query, quizID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var questions []*quiz.Question
-	for rows.Next() {
-		var q quiz.Question
-		if err := rows.Scan(
-			&q.ID, &q.QuizID, &q.Prompt,
-			&q.OptionA, &q.OptionB, &q.OptionC, &q.OptionD,
-			&q.CorrectIndex, &q.SortOrder,
-			&q.CreatedAt, &q.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		questions = append(questions, &q)
-	}
-	return questions, nil
+	return findQuestionsByQuizID(ctx, r.db, quizID)
 }
 
 func (r *SQLiteQuestionRepository) ReorderQuestions(ctx context.Context, quizID string, questions []*quiz.Question) error {
@@ -86,17 +51,129 @@ func (r *SQLiteQuestionRepository) ReorderQuestions(ctx context.Context, quizID
 	return tx.Commit()
 }
 
-// Método extra para Update que não está na interface genérica mas é útil?
-// A interface diz Save, Delete, etc. Se precisar Update, adicionamos na interface.
-// Vamos adicionar Update na interface depois ou usar Save como upsert? Simplifique: ADICIONAR Update na interface QuestionRepo.
+// Update grava os campos editáveis da pergunta (tipo, enunciado, alternativas e gabarito).
 func (r *SQLiteQuestionRepository) Update(ctx context.Context, q *quiz.Question) error {
+	return updateQuestion(ctx, r.db, q)
+}
+
+// ------ Consultas compartilhadas com SQLiteQuizRepository ------
+
+// questionColumns lista as colunas lidas por scanQuestion, na mesma ordem.
+const questionColumns = `id, quiz_id, type, prompt, options, correct_indexes, accepted_answers, numeric_answer, tolerance, items, match_left, match_right, time_limit_seconds, points_multiplier, sort_order, created_at, updated_at`
+
+func insertQuestion(ctx context.Context, db *sql.DB, q *quiz.Question) error {
+	legacy := legacyColumns(q)
+	query := `
+		INSERT INTO questions (id, quiz_id, type, prompt, options, correct_indexes, accepted_answers, numeric_answer, tolerance, items, match_left, match_right, option_a, option_b, option_c, option_d, correct_index, time_limit_seconds, points_multiplier, sort_order, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := db.ExecContext(ctx, query,
+		q.ID, q.QuizID, q.Type, q.Prompt,
+		toJson(q.Options), toJson(q.CorrectIndexes),
+		toJson(nonNilStrings(q.AcceptedAnswers)), q.NumericAnswer, q.Tolerance,
+		toJson(nonNilStrings(q.Items)), toJson(nonNilStrings(q.MatchLeft)), toJson(nonNilStrings(q.MatchRight)),
+		legacy[0], legacy[1], legacy[2], legacy[3], legacy[4],
+		q.TimeLimit, q.PointsMultiplier, q.SortOrder,
+		q.CreatedAt, q.UpdatedAt,
+	)
+	return err
+}
+
+func updateQuestion(ctx context.Context, db *sql.DB, q *quiz.Question) error {
+	legacy := legacyColumns(q)
 	query := `
-		UPDATE questions 
-		SET prompt = ?, option_a = ?, option_b = ?, option_c = ?, option_d = ?, correct_index = ?, updated_at = ?
+		UPDATE questions
+		SET type = ?, prompt = ?, options = ?, correct_indexes = ?,
+			accepted_answers = ?, numeric_answer = ?, tolerance = ?,
+			items = ?, match_left = ?, match_right = ?,
+			option_a = ?, option_b = ?, option_c = ?, option_d = ?, correct_index = ?,
+			time_limit_seconds = ?, points_multiplier = ?, updated_at = ?
 		WHERE id = ?
 	`
-	_, err := r.db.ExecContext(ctx, query,
-		q.Prompt, q.OptionA, q.OptionB, q.OptionC, q.OptionD, q.CorrectIndex, q.UpdatedAt, q.ID,
+	_, err := db.ExecContext(ctx, query,
+		q.Type, q.Prompt, toJson(q.Options), toJson(q.CorrectIndexes),
+		toJson(nonNilStrings(q.AcceptedAnswers)), q.NumericAnswer, q.Tolerance,
+		toJson(nonNilStrings(q.Items)), toJson(nonNilStrings(q.MatchLeft)), toJson(nonNilStrings(q.MatchRight)),
+		legacy[0], legacy[1], legacy[2], legacy[3], legacy[4],
+		q.TimeLimit, q.PointsMultiplier, q.UpdatedAt, q.ID,
 	)
 	return err
 }
+
+func findQuestionsByQuizID(ctx context.Context, db *sql.DB, quizID string) ([]*quiz.Question, error) {
+	query := `SELECT ` + questionColumns + ` FROM questions WHERE quiz_id = ? ORDER BY sort_order ASC`
+	rows, err := db.QueryContext(ctx, query, quizID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []*quiz.Question
+	for rows.Next() {
+		var q quiz.Question
+		var options, correct, accepted, items, matchLeft, matchRight string
+		var numeric sql.NullFloat64
+		if err := rows.Scan(
+			&q.ID, &q.QuizID, &q.Type, &q.Prompt,
+			&options, &correct,
+			&accepted, &numeric, &q.Tolerance,
+			&items, &matchLeft, &matchRight,
+			&q.TimeLimit, &q.PointsMultiplier, &q.SortOrder,
+			&q.CreatedAt, &q.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(correct), &q.CorrectIndexes); err != nil {
+			return nil, err
+		}
+		for _, col := range []struct {
+			raw  string
+			dest *[]string
+		}{
+			{accepted, &q.AcceptedAnswers},
+			{items, &q.Items},
+			{matchLeft, &q.MatchLeft},
+			{matchRight, &q.MatchRight},
+		} {
+			if err := json.Unmarshal([]byte(col.raw), col.dest); err != nil {
+				return nil, err
+			}
+			if len(*col.dest) == 0 {
+				*col.dest = nil // Campo de outro tipo de pergunta
+			}
+		}
+		if numeric.Valid {
+			q.NumericAnswer = &numeric.Float64
+		}
+		questions = append(questions, &q)
+	}
+	return questions, rows.Err()
+}
+
+// legacyColumns preenche as colunas option_a..option_d e correct_index (NOT NULL desde a
+// migração 002) com as 4 primeiras alternativas e a primeira resposta correta.
+func legacyColumns(q *quiz.Question) [5]interface{} {
+	var cols [5]interface{}
+	for i := 0; i < 4; i++ {
+		cols[i] = ""
+		if i < len(q.Options) {
+			cols[i] = q.Options[i]
+		}
+	}
+	cols[4] = -1
+	if len(q.CorrectIndexes) > 0 {
+		cols[4] = q.CorrectIndexes[0]
+	}
+	return cols
+}
+
+// nonNilStrings troca nil por lista vazia (grava "[]" em vez de "null").
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -19,11 +19,11 @@ func NewSQLiteQuizRepository(db *sql.DB) *SQLiteQuizRepository {
 
 func (r *SQLiteQuizRepository) Save(ctx context.Context, q *quiz.Quiz) error {
 	query := `
-		INSERT INTO quizzes (id, teacher_id, title, description, subject, grade, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO quizzes (id, teacher_id, title, description, subject, grade, status, scoring_mode, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.ExecContext(ctx, query,
-		q.ID, q.TeacherID, q.Title, q.Description, q.Subject, q.Grade, q.Status, q.CreatedAt, q.UpdatedAt,
+		q.ID, q.TeacherID, q.Title, q.Description, q.Subject, q.Grade, q.Status, q.ScoringMode, q.CreatedAt, q.UpdatedAt,
 	)
 	return err
 }
@@ -31,18 +31,18 @@ func (r *SQLiteQuizRepository) Save(ctx context.Context, q *quiz.Quiz) error {
 func (r *SQLiteQuizRepository) Update(ctx context.Context, q *quiz.Quiz) error {
 	query := `
 		UPDATE quizzes 
-		SET title = ?, description = ?, subject = ?, grade = ?, status = ?, updated_at = ?
+		SET title = ?, description = ?, subject = ?, grade = ?, status = ?, scoring_mode = ?, updated_at = ?
 		WHERE id = ?
 	`
 	_, err := r.db.ExecContext(ctx, query,
-		q.Title, q.Description, q.Subject, q.Grade, q.Status, q.UpdatedAt, q.ID,
+		q.Title, q.Description, q.Subject, q.Grade, q.Status, q.ScoringMode, q.UpdatedAt, q.ID,
 	)
 	return err
 }
 
 func (r *SQLiteQuizRepository) FindByID(ctx context.Context, id string) (*quiz.Quiz, error) {
 	query := `
-		SELECT id, teacher_id, title, description, subject, grade, status, created_at, updated_at
+		SELECT id, teacher_id, title, description, subject, grade, status, scoring_mode, created_at, updated_at
 		FROM quizzes WHERE id = ?
 	`
 	row := r.db.QueryRowContext(ctx, query, id)
@@ -52,7 +52,7 @@ func (r *SQLiteQuizRepository) FindByID(ctx context.Context, id string) (*quiz.Q
 
 	err := row.Scan(
 		&q.ID, &q.TeacherID, &q.Title, &desc, &subj, &grade,
-		&q.Status, &q.CreatedAt, &q.UpdatedAt,
+		&q.Status, &q.ScoringMode, &q.CreatedAt, &q.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -80,7 +80,7 @@ func (r *SQLiteQuizRepository) FindByID(ctx context.Context, id string) (*quiz.Q
 
 func (r *SQLiteQuizRepository) FindByTeacherID(ctx context.Context, teacherID string) ([]*quiz.Quiz, error) {
 	query := `
-		SELECT id, teacher_id, title, description, subject, grade, status, created_at, updated_at
+		SELECT id, teacher_id, title, description, subject, grade, status, scoring_mode, created_at, updated_at
 		FROM quizzes WHERE teacher_id = ? ORDER BY created_at DESC
 	`
 	rows, err := r.db.QueryContext(ctx, query, teacherID)
@@ -96,7 +96,7 @@ func (r *SQLiteQuizRepository) FindByTeacherID(ctx context.Context, teacherID st
 
 		if err := rows.Scan(
 			&q.ID, &q.TeacherID, &q.Title, &desc, &subj, &grade,
-			&q.Status, &q.CreatedAt, &q.UpdatedAt,
+			&q.Status, &q.ScoringMode, &q.CreatedAt, &q.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -123,46 +123,11 @@ func (r *SQLiteQuizRepository) Delete(ctx context.Context, id string) error {
 
 // FindByQuizID é usado tanto internamente quanto externamente.
 func (r *SQLiteQuizRepository) FindByQuizID(ctx context.Context, quizID string) ([]*quiz.Question, error) {
-	query := `
-		SELECT id, quiz_id, prompt, option_a, option_b, option_c, option_d, correct_index, sort_order, created_at, updated_at
-		FROM questions
-		WHERE quiz_id = ?
-		ORDER BY sort_order ASC
-	`
-	rows, err := r.db.QueryContext(ctx, query, quizID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var questions []*quiz.Question
-	for rows.Next() {
-		var q quiz.Question
-		if err := rows.Scan(
-			&q.ID, &q.QuizID, &q.Prompt,
-			&q.OptionA, &q.OptionB, &q.OptionC, &q.OptionD,
-			&q.CorrectIndex, &q.SortOrder,
-			&q.CreatedAt, &q.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		questions = append(questions, &q)
-	}
-	return questions, nil
+	return findQuestionsByQuizID(ctx, r.db, quizID)
 }
 
 func (r *SQLiteQuizRepository) SaveQuestion(ctx context.Context, q *quiz.Question) error {
-	query := `
-		INSERT INTO questions (id, quiz_id, prompt, option_a, option_b, option_c, option_d, correct_index, sort_order, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := r.db.ExecContext(ctx, query,
-		q.ID, q.QuizID, q.Prompt,
-		q.OptionA, q.OptionB, q.OptionC, q.OptionD,
-		q.CorrectIndex, q.SortOrder,
-		q.CreatedAt, q.UpdatedAt,
-	)
-	return err
+	return insertQuestion(ctx, r.db, q)
 }
 
 func (r *SQLiteQuizRepository) DeleteQuestion(ctx context.Context, id string) error {
@@ -172,15 +137,7 @@ func (r *SQLiteQuizRepository) DeleteQuestion(ctx context.Context, id string) er
 
 // UpdateQuestion atualiza uma pergunta existente
 func (r *SQLiteQuizRepository) UpdateQuestion(ctx context.Context, q *quiz.Question) error {
-	query := `
-		UPDATE questions 
-		SET prompt = ?, option_a = ?, option_b = ?, option_c = ?, option_d = ?, correct_index = ?, updated_at = ?
-		WHERE id = ?
-	`
-	_, err := r.db.ExecContext(ctx, query,
-		q.Prompt, q.OptionA, q.OptionB, q.OptionC, q.OptionD, q.CorrectIndex, q.UpdatedAt, q.ID,
-	)
-	return err
+	return updateQuestion(ctx, r.db, q)
 }
 
 // ReorderQuestions atualiza a ordem de várias perguntas.
@@ -7,7 +7,10 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWTService implementa a interface TokenService.
+// rejoinTokenType identifica tokens de reconexão de alunos (claim "typ").
+const rejoinTokenType = "rejoin"
+
+// JWTService implementa as interfaces TokenService e RejoinTokenService.
 type JWTService struct {
 	secretKey []byte
 	issuer    string
@@ -62,6 +65,11 @@ func (s *JWTService) ValidateToken(tokenString string) (string, error) {
 			return "", errors.New("token expirado")
 		}
 
+		// Token de reconexão de aluno não autentica professor
+		if typ, _ := claims["typ"].(string); typ == rejoinTokenType {
+			return "", errors.New("token inválido")
+		}
+
 		userID, ok := claims["sub"].(string)
 		if !ok {
 			return "", errors.New("token sem ID de usuário (sub)")
@@ -72,3 +80,48 @@ func (s *JWTService) ValidateToken(tokenString string) (string, error) {
 
 	return "", errors.New("token inválido")
 }
+
+// GenerateRejoinToken gera um token que permite ao aluno retomar seu lugar na sala.
+func (s *JWTService) GenerateRejoinToken(roomID, playerID string) (string, error) {
+	expiresIn := time.Duration(12) * time.Hour // Cobre um dia de aula
+
+	claims := jwt.MapClaims{
+		"sub":  playerID,
+		"room": roomID,
+		"typ":  rejoinTokenType,
+		"iss":  s.issuer,
+		"exp":  time.Now().Add(expiresIn).Unix(),
+		"iat":  time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secretKey)
+}
+
+// ValidateRejoinToken valida o token de reconexão e retorna sala e jogador.
+func (s *JWTService) ValidateRejoinToken(tokenString string) (string, string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("método de assinatura inválido")
+		}
+		return s.secretKey, nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", "", errors.New("token inválido")
+	}
+	if typ, _ := claims["typ"].(string); typ != rejoinTokenType {
+		return "", "", errors.New("token não é de reconexão")
+	}
+
+	roomID, _ := claims["room"].(string)
+	playerID, _ := claims["sub"].(string)
+	if roomID == "" || playerID == "" {
+		return "", "", errors.New("token de reconexão incompleto")
+	}
+	return roomID, playerID, nil
+}
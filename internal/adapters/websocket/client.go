@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"rankit/internal/ports"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -25,11 +26,19 @@ var upgrader = websocket.Upgrader{
 }
 
 type Client struct {
-	Hub      *Hub
-	Conn     *websocket.Conn
-	Send     chan []byte
-	RoomID   string
-	PlayerID string
+	Hub       *Hub
+	Conn      *websocket.Conn
+	Send      chan []byte
+	RoomID    string
+	PlayerID  string
+	TeacherID string // Preenchido apenas se a conexão apresentou um JWT válido do dono da sala
+	Role      string // ports.RoleTeacher, ports.RolePlayer ou ports.RoleSpectator
+	Rejoining bool   // Conexão retomando um jogador existente via token de reconexão
+}
+
+// IsTeacher indica se a conexão foi autenticada como professor da sala.
+func (c *Client) IsTeacher() bool {
+	return c.Role == ports.RoleTeacher
 }
 
 func (c *Client) readPump() {
@@ -1,28 +1,42 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"rankit/internal/application/usecases"
+	"rankit/internal/domain/game"
+	"rankit/internal/domain/quiz"
+	"rankit/internal/ports"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
+// bearerProtocol é o subprotocolo usado para enviar o JWT via Sec-WebSocket-Protocol
+// (navegadores não permitem header Authorization no handshake): "bearer, <token>".
+const bearerProtocol = "bearer"
+
 // WebSocketHandler gerencia o upgrade e o roteamento de eventos.
 type WebSocketHandler struct {
-	hub    *Hub
-	gameUC *usecases.GameUseCases
+	hub          *Hub
+	gameUC       *usecases.GameUseCases
+	tokenService ports.TokenService
 }
 
-func NewWebSocketHandler(hub *Hub, gameUC *usecases.GameUseCases) *WebSocketHandler {
+func NewWebSocketHandler(hub *Hub, gameUC *usecases.GameUseCases, tokenService ports.TokenService) *WebSocketHandler {
 	handler := &WebSocketHandler{
-		hub:    hub,
-		gameUC: gameUC,
+		hub:          hub,
+		gameUC:       gameUC,
+		tokenService: tokenService,
 	}
 
-	// Registra o callback no Hub
+	// Registra os callbacks no Hub
 	hub.EventHandler = handler.HandleEvent
+	hub.ConnectHandler = handler.HandleConnect
+	hub.DisconnectHandler = handler.HandleDisconnect
 	return handler
 }
 
@@ -38,20 +52,73 @@ func (h *WebSocketHandler) HandleWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	conn, err := upgrader.Upgrade(w, r, nil)
+	// Aceita o código de entrada da sala; o Hub sempre trabalha com o ID interno
+	roomID, err := h.gameUC.ResolveRoomID(roomID)
 	if err != nil {
-		log.Println("Upgrade error:", err)
+		http.Error(w, "Sala não encontrada", http.StatusNotFound)
 		return
 	}
 
+	// Tela de projeção se autentica com o código emitido pelo professor; apenas acompanha o jogo
+	role := ports.RolePlayer
+	if displayCode := r.URL.Query().Get("display"); displayCode != "" {
+		if err := h.gameUC.AuthorizeDisplay(roomID, displayCode); err != nil {
+			http.Error(w, "Código de tela inválido", http.StatusUnauthorized)
+			return
+		}
+		role = ports.RoleSpectator
+	}
+
+	// Professor se autentica com o JWT; sem token a conexão só pode enviar eventos de aluno
+	var teacherID string
+	var responseHeader http.Header
+	token, viaProtocol := extractToken(r)
+	if token != "" && role != ports.RoleSpectator {
+		userID, err := h.tokenService.ValidateToken(token)
+		if err != nil {
+			http.Error(w, "Token inválido ou expirado: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		// O canal do professor recebe dados que os alunos não veem: só o dono da sala entra nele
+		if !h.gameUC.IsRoomTeacher(roomID, userID) {
+			http.Error(w, "Sala pertence a outro professor", http.StatusForbidden)
+			return
+		}
+		teacherID = userID
+		role = ports.RoleTeacher
+		if viaProtocol {
+			responseHeader = http.Header{"Sec-WebSocket-Protocol": {bearerProtocol}}
+		}
+	}
+
+	// Aluno retomando o lugar após queda de conexão
 	sessionID := uuid.NewString()
+	rejoining := false
+	if rejoinToken := r.URL.Query().Get("rejoinToken"); rejoinToken != "" && role == ports.RolePlayer {
+		playerID, err := h.gameUC.ResolveRejoinToken(roomID, rejoinToken)
+		if err != nil {
+			http.Error(w, "Token de reconexão inválido: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		sessionID = playerID
+		rejoining = true
+	}
+
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		log.Println("Upgrade error:", err)
+		return
+	}
 
 	client := &Client{
-		Hub:      h.hub,
-		Conn:     conn,
-		Send:     make(chan []byte, 256),
-		RoomID:   roomID,
-		PlayerID: sessionID,
+		Hub:       h.hub,
+		Conn:      conn,
+		Send:      make(chan []byte, 256),
+		RoomID:    roomID,
+		PlayerID:  sessionID,
+		TeacherID: teacherID,
+		Role:      role,
+		Rejoining: rejoining,
 	}
 
 	client.Hub.register <- client
@@ -60,8 +127,41 @@ func (h *WebSocketHandler) HandleWS(w http.ResponseWriter, r *http.Request) {
 	go client.readPump()
 }
 
+// HandleConnect é chamado pelo Hub após registrar a conexão.
+func (h *WebSocketHandler) HandleConnect(client *Client) {
+	if client.Role == ports.RoleSpectator {
+		if err := h.gameUC.ConnectDisplay(client.RoomID, client.PlayerID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+		return
+	}
+	if !client.Rejoining {
+		return
+	}
+	if err := h.gameUC.ReconnectPlayer(client.RoomID, client.PlayerID); err != nil {
+		h.hub.SendToPlayer(client.PlayerID, map[string]interface{}{
+			"type":    "rejoin_failed",
+			"payload": err.Error(),
+		})
+	}
+}
+
+// HandleDisconnect é chamado pelo Hub quando a conexão cai.
+func (h *WebSocketHandler) HandleDisconnect(client *Client) {
+	if client.Role != ports.RolePlayer {
+		return
+	}
+	h.gameUC.DisconnectPlayer(client.RoomID, client.PlayerID)
+}
+
 // HandleEvent processa mensagens vindas dos clientes (Router de Eventos).
 func (h *WebSocketHandler) HandleEvent(client *Client, msg Envelope) {
+	// A tela de projeção não joga nem conduz a sala
+	if client.Role == ports.RoleSpectator {
+		h.sendError(client.PlayerID, game.ErrTelaSomenteLeitura.Error())
+		return
+	}
+
 	switch msg.Type {
 	case "join_room":
 		var payload struct {
@@ -69,62 +169,168 @@ func (h *WebSocketHandler) HandleEvent(client *Client, msg Envelope) {
 		}
 		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
 			_, err := h.gameUC.JoinRoom(client.RoomID, payload.Nickname, client.PlayerID)
-			if err != nil {
+			var reason *game.Error
+			if err != nil && !errors.As(err, &reason) { // Recusas com código já saíram como join_rejected
 				h.sendError(client.PlayerID, err.Error())
 			}
 		}
 
 	case "teacher_moderate_entry":
+		if !h.requireTeacher(client) {
+			return
+		}
 		var payload struct {
-			TeacherID    string `json:"teacherId"`
 			ConnectionID string `json:"connectionId"`
 			Action       string `json:"action"` // ACCEPT | REJECT
 		}
 		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
-			if err := h.gameUC.ModerateEntry(client.RoomID, payload.TeacherID, payload.ConnectionID, payload.Action); err != nil {
+			if err := h.gameUC.ModerateEntry(client.RoomID, client.TeacherID, payload.ConnectionID, payload.Action); err != nil {
 				h.sendError(client.PlayerID, err.Error())
 			}
 		}
 
 	case "teacher_kick_player":
+		if !h.requireTeacher(client) {
+			return
+		}
 		var payload struct {
-			TeacherID    string `json:"teacherId"`
 			ConnectionID string `json:"connectionId"`
 		}
 		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
-			if err := h.gameUC.KickPlayer(client.RoomID, payload.TeacherID, payload.ConnectionID); err != nil {
+			if err := h.gameUC.KickPlayer(client.RoomID, client.TeacherID, payload.ConnectionID); err != nil {
 				h.sendError(client.PlayerID, err.Error())
 			}
 		}
 
-	case "teacher_open_question":
+	case "teacher_define_teams":
+		if !h.requireTeacher(client) {
+			return
+		}
 		var payload struct {
-			TeacherID string `json:"teacherId"`
+			Teams []string `json:"teams"` // Nomes dos times
 		}
 		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
-			if err := h.gameUC.OpenQuestion(client.RoomID, payload.TeacherID); err != nil {
+			if err := h.gameUC.DefineTeams(client.RoomID, client.TeacherID, payload.Teams); err != nil {
 				h.sendError(client.PlayerID, err.Error())
 			}
 		}
 
-	case "submit_answer":
+	case "teacher_assign_team":
+		if !h.requireTeacher(client) {
+			return
+		}
 		var payload struct {
-			AnswerIndex int `json:"answerIndex"`
+			ConnectionID string `json:"connectionId"`
+			TeamID       string `json:"teamId"`
 		}
 		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
-			if err := h.gameUC.SubmitAnswer(client.RoomID, client.PlayerID, payload.AnswerIndex); err != nil {
+			if err := h.gameUC.AssignTeam(client.RoomID, client.TeacherID, payload.ConnectionID, payload.TeamID); err != nil {
 				h.sendError(client.PlayerID, err.Error())
 			}
 		}
 
-	case "teacher_reveal":
+	case "teacher_start_game":
+		if !h.requireTeacher(client) {
+			return
+		}
 		var payload struct {
-			TeacherID string `json:"teacherId"`
+			AutoRejectPending bool `json:"autoRejectPending"` // Recusa quem ainda aguarda aprovação
 		}
-		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
-			if err := h.gameUC.RevealQuestion(client.RoomID, payload.TeacherID); err != nil {
-				h.sendError(client.PlayerID, err.Error())
-			}
+		// Payload opcional: sem ele, os pendentes continuam aguardando
+		_ = json.Unmarshal(msg.Payload, &payload)
+		if err := h.gameUC.StartGame(client.RoomID, client.TeacherID, payload.AutoRejectPending); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_end_game":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.EndGame(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_open_question":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.OpenQuestion(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "submit_answer":
+		var payload struct {
+			AnswerIndex   *int     `json:"answerIndex"`   // Uma alternativa
+			AnswerIndexes []int    `json:"answerIndexes"` // Várias alternativas (MULTI_SELECT)
+			AnswerText    string   `json:"answerText"`    // Resposta digitada (OPEN_TEXT e NUMERIC)
+			AnswerOrder   []int    `json:"answerOrder"`   // ORDERING: posições exibidas, na ordem escolhida
+			AnswerPairs   [][2]int `json:"answerPairs"`   // MATCHING: pares [esquerda, direita exibida]
+		}
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			h.gameUC.RejectAnswer(client.PlayerID, game.ErrRespostaMalformada)
+			return
+		}
+		resp := quiz.Response{
+			Indexes: payload.AnswerIndexes,
+			Text:    payload.AnswerText,
+			Order:   payload.AnswerOrder,
+			Pairs:   payload.AnswerPairs,
+		}
+		if resp.Indexes == nil && payload.AnswerIndex != nil {
+			resp.Indexes = []int{*payload.AnswerIndex}
+		}
+		err := h.gameUC.SubmitAnswer(client.RoomID, client.PlayerID, resp)
+		var reason *game.Error
+		if err != nil && !errors.As(err, &reason) { // Recusas com código já saíram como answer_rejected/answer_locked
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_reveal":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.RevealQuestion(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_pause":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.PauseRoom(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_resume":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.ResumeRoom(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_skip_question":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.SkipQuestion(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_previous_question":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.PreviousQuestion(client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
+		}
+
+	case "teacher_close_room":
+		if !h.requireTeacher(client) {
+			return
+		}
+		if err := h.gameUC.CloseRoom(context.Background(), client.RoomID, client.TeacherID); err != nil {
+			h.sendError(client.PlayerID, err.Error())
 		}
 
 	default:
@@ -132,6 +338,29 @@ func (h *WebSocketHandler) HandleEvent(client *Client, msg Envelope) {
 	}
 }
 
+// requireTeacher bloqueia eventos de professor vindos de conexões sem JWT.
+func (h *WebSocketHandler) requireTeacher(client *Client) bool {
+	if !client.IsTeacher() {
+		h.sendError(client.PlayerID, game.ErrPermissaoProfessor.Error())
+		return false
+	}
+	return true
+}
+
+// extractToken busca o JWT na query (?token=) ou no header Sec-WebSocket-Protocol ("bearer, <token>").
+// O segundo retorno indica se o token veio pelo subprotocolo (que precisa ser ecoado no handshake).
+func extractToken(r *http.Request) (string, bool) {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token, false
+	}
+
+	protocols := strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",")
+	if len(protocols) == 2 && strings.EqualFold(strings.TrimSpace(protocols[0]), bearerProtocol) {
+		return strings.TrimSpace(protocols[1]), true
+	}
+	return "", false
+}
+
 func (h *WebSocketHandler) sendError(playerID, errorMsg string) {
 	h.hub.SendToPlayer(playerID, map[string]interface{}{
 		"type":    "error",
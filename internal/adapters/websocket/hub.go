@@ -25,6 +25,10 @@ type Hub struct {
 	// Handler processa eventos de negócio (injetado via setter ou campo)
 	EventHandler func(*Client, Envelope)
 
+	// ConnectHandler e DisconnectHandler são notificados quando uma conexão entra ou sai do Hub
+	ConnectHandler    func(*Client)
+	DisconnectHandler func(*Client)
+
 	// Mapeia PlayerID -> Client (para envio direto)
 	playerSessions map[string]*Client
 
@@ -44,26 +48,49 @@ func NewHub() *Hub {
 
 // Implementação da interface RealTimeHub
 func (h *Hub) BroadcastToRoom(roomID string, message interface{}) {
+	h.broadcast(roomID, message, func(*Client) bool { return true })
+}
+
+func (h *Hub) BroadcastToRole(roomID, role string, message interface{}) {
+	h.broadcast(roomID, message, func(c *Client) bool { return c.Role == role })
+}
+
+func (h *Hub) BroadcastToRoleExcept(roomID, role, exceptPlayerID string, message interface{}) {
+	h.broadcast(roomID, message, func(c *Client) bool { return c.Role == role && c.PlayerID != exceptPlayerID })
+}
+
+// broadcast envia a mensagem aos clientes da sala aceitos pelo filtro.
+func (h *Hub) broadcast(roomID string, message interface{}, accept func(*Client) bool) {
 	bytes, err := json.Marshal(message)
 	if err != nil {
 		log.Println("Erro ao serializar broadcast:", err)
 		return
 	}
 
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	// Clientes com o buffer cheio são descartados depois, sob o lock de escrita
+	var slow []*Client
 
-	if clients, ok := h.rooms[roomID]; ok {
-		for client := range clients {
-			select {
-			case client.Send <- bytes:
-			default:
-				close(client.Send)
-				delete(h.clients, client)
-				delete(clients, client)
-			}
+	h.mu.RLock()
+	for client := range h.rooms[roomID] {
+		if !accept(client) {
+			continue
+		}
+		select {
+		case client.Send <- bytes:
+		default:
+			slow = append(slow, client)
 		}
 	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+	h.mu.Lock()
+	for _, client := range slow {
+		h.removeClient(client)
+	}
+	h.mu.Unlock()
 }
 
 func (h *Hub) SendToPlayer(playerID string, message interface{}) {
@@ -73,11 +100,11 @@ func (h *Hub) SendToPlayer(playerID string, message interface{}) {
 		return
 	}
 
+	// O envio acontece sob o lock de leitura para o canal não ser fechado no meio
 	h.mu.RLock()
-	client, ok := h.playerSessions[playerID]
-	h.mu.RUnlock()
+	defer h.mu.RUnlock()
 
-	if ok {
+	if client, ok := h.playerSessions[playerID]; ok {
 		select {
 		case client.Send <- bytes:
 		default:
@@ -86,6 +113,17 @@ func (h *Hub) SendToPlayer(playerID string, message interface{}) {
 	}
 }
 
+// CloseRoom desconecta todos os clientes da sala (o writePump envia o frame de close).
+func (h *Hub) CloseRoom(roomID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.rooms[roomID] {
+		h.removeClient(client)
+	}
+	delete(h.rooms, roomID)
+}
+
 func (h *Hub) Run() {
 	for {
 		select {
@@ -98,27 +136,28 @@ func (h *Hub) Run() {
 			h.rooms[client.RoomID][client] = true
 
 			if client.PlayerID != "" {
+				// Reconexão: a conexão antiga do mesmo jogador é descartada
+				if old, ok := h.playerSessions[client.PlayerID]; ok && old != client {
+					h.removeClient(old)
+				}
 				h.playerSessions[client.PlayerID] = client
 			}
 			h.mu.Unlock()
 
+			if h.ConnectHandler != nil {
+				go h.ConnectHandler(client)
+			}
+
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				if clients, ok := h.rooms[client.RoomID]; ok {
-					delete(clients, client)
-					if len(clients) == 0 {
-						delete(h.rooms, client.RoomID)
-					}
-				}
-				if client.PlayerID != "" {
-					delete(h.playerSessions, client.PlayerID)
-				}
-				close(client.Send)
-			}
+			active := h.removeClient(client)
 			h.mu.Unlock()
 
+			// Conexões já substituídas por uma reconexão não disparam o aviso
+			if active && h.DisconnectHandler != nil {
+				go h.DisconnectHandler(client)
+			}
+
 		case msg := <-h.IncomingMsgs:
 			// Delega para o handler de negócio
 			if h.EventHandler != nil {
@@ -127,3 +166,24 @@ func (h *Hub) Run() {
 		}
 	}
 }
+
+// removeClient tira o cliente de todos os índices e fecha seu canal de envio.
+// É idempotente: retorna false se o cliente já tinha sido removido.
+// Deve ser chamado com o lock adquirido.
+func (h *Hub) removeClient(client *Client) bool {
+	if !h.clients[client] {
+		return false
+	}
+	delete(h.clients, client)
+	if clients, ok := h.rooms[client.RoomID]; ok {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(h.rooms, client.RoomID)
+		}
+	}
+	if client.PlayerID != "" && h.playerSessions[client.PlayerID] == client {
+		delete(h.playerSessions, client.PlayerID)
+	}
+	close(client.Send)
+	return true
+}
@@ -0,0 +1,179 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestClient cria um cliente sem conexão real; só o canal Send é usado pelo Hub.
+func newTestClient(hub *Hub, roomID, playerID string, buffer int) *Client {
+	return &Client{
+		Hub:      hub,
+		Send:     make(chan []byte, buffer),
+		RoomID:   roomID,
+		PlayerID: playerID,
+	}
+}
+
+// flush espera o Hub terminar o comando anterior: o loop de Run é sequencial,
+// então quando uma nova mensagem é aceita a anterior já foi processada.
+func flush(hub *Hub) {
+	hub.IncomingMsgs <- HubMessage{}
+}
+
+// receive lê a próxima mensagem do cliente ou falha após o timeout.
+func receive(t *testing.T, c *Client) []byte {
+	t.Helper()
+	select {
+	case msg, ok := <-c.Send:
+		if !ok {
+			t.Fatal("canal de envio fechado inesperadamente")
+		}
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("nenhuma mensagem recebida")
+	}
+	return nil
+}
+
+// assertClosed verifica que o canal de envio do cliente foi fechado pelo Hub.
+func assertClosed(t *testing.T, c *Client) {
+	t.Helper()
+	select {
+	case _, ok := <-c.Send:
+		if ok {
+			t.Fatal("esperava canal fechado, recebeu mensagem")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("canal de envio não foi fechado")
+	}
+}
+
+func TestHubReconnect(t *testing.T) {
+	tests := []struct {
+		name string
+		// dropOld faz o broadcast descartar a conexão antiga antes da reconexão
+		dropOld bool
+	}{
+		{name: "conexão antiga ativa", dropOld: false},
+		{name: "conexão antiga já descartada pelo broadcast", dropOld: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub()
+			go hub.Run()
+
+			buffer := 1
+			if tt.dropOld {
+				buffer = 0 // Sem buffer e sem leitor: todo envio falha
+			}
+			old := newTestClient(hub, "room-1", "player-1", buffer)
+			hub.register <- old
+			flush(hub)
+
+			if tt.dropOld {
+				hub.BroadcastToRoom("room-1", map[string]string{"type": "ping"})
+				assertClosed(t, old)
+			}
+
+			// Reconexão do mesmo jogador não pode derrubar o Hub
+			fresh := newTestClient(hub, "room-1", "player-1", 4)
+			hub.register <- fresh
+			flush(hub)
+
+			if !tt.dropOld {
+				assertClosed(t, old)
+			}
+
+			hub.SendToPlayer("player-1", map[string]string{"type": "hello"})
+			if got := string(receive(t, fresh)); got != `{"type":"hello"}` {
+				t.Fatalf("mensagem inesperada: %s", got)
+			}
+
+			// A conexão antiga se desregistra depois; não pode afetar a nova
+			hub.unregister <- old
+			flush(hub)
+			hub.BroadcastToRoom("room-1", map[string]string{"type": "still-here"})
+			if got := string(receive(t, fresh)); got != `{"type":"still-here"}` {
+				t.Fatalf("mensagem inesperada: %s", got)
+			}
+		})
+	}
+}
+
+func TestHubSendToDroppedPlayer(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	slow := newTestClient(hub, "room-1", "player-1", 0)
+	hub.register <- slow
+	flush(hub)
+
+	hub.BroadcastToRoom("room-1", map[string]string{"type": "ping"})
+	assertClosed(t, slow)
+
+	// A sessão do jogador descartado não existe mais: o envio direto é ignorado
+	hub.SendToPlayer("player-1", map[string]string{"type": "hello"})
+
+	hub.mu.RLock()
+	_, ok := hub.playerSessions["player-1"]
+	hub.mu.RUnlock()
+	if ok {
+		t.Fatal("sessão do jogador descartado continua registrada")
+	}
+}
+
+func TestHubBroadcastToRole(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	teacher := newTestClient(hub, "room-1", "", 1)
+	teacher.Role = "teacher"
+	player := newTestClient(hub, "room-1", "player-1", 1)
+	player.Role = "player"
+	other := newTestClient(hub, "room-2", "player-2", 1)
+	other.Role = "teacher"
+	hub.register <- teacher
+	hub.register <- player
+	hub.register <- other
+	flush(hub)
+
+	hub.BroadcastToRole("room-1", "teacher", map[string]string{"type": "stats"})
+	receive(t, teacher)
+
+	for _, c := range []*Client{player, other} {
+		select {
+		case msg := <-c.Send:
+			t.Fatalf("cliente fora do público recebeu %s", msg)
+		default:
+		}
+	}
+}
+
+func TestHubBroadcastToRoleExcept(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	joined := newTestClient(hub, "room-1", "player-1", 1)
+	joined.Role = "player"
+	classmate := newTestClient(hub, "room-1", "player-2", 1)
+	classmate.Role = "player"
+	teacher := newTestClient(hub, "room-1", "", 1)
+	teacher.Role = "teacher"
+	hub.register <- joined
+	hub.register <- classmate
+	hub.register <- teacher
+	flush(hub)
+
+	hub.BroadcastToRoleExcept("room-1", "player", "player-1", map[string]string{"type": "player_joined"})
+	receive(t, classmate)
+
+	for _, c := range []*Client{joined, teacher} {
+		select {
+		case msg := <-c.Send:
+			t.Fatalf("cliente fora do público recebeu %s", msg)
+		default:
+		}
+	}
+}
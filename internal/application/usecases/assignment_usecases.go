@@ -0,0 +1,515 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"rankit/internal/domain/assignment"
+	"rankit/internal/domain/history"
+	"rankit/internal/domain/quiz"
+	"rankit/internal/infra/logger"
+	"rankit/internal/ports"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTarefaNaoEncontrada    = errors.New("tarefa não encontrada")
+	ErrTentativaNaoEncontrada = errors.New("tentativa não encontrada")
+	ErrApelidoEmUsoTarefa     = errors.New("apelido já em uso nesta tarefa")
+)
+
+// ------ ASSIGNMENT METHODS (Professor) ------
+
+type AssignmentUseCases struct {
+	assignmentRepo ports.AssignmentRepository
+	quizRepo       ports.QuizRepository
+	historyRepo    ports.HistoryRepository
+}
+
+func NewAssignmentUseCases(assignmentRepo ports.AssignmentRepository, quizRepo ports.QuizRepository, historyRepo ports.HistoryRepository) *AssignmentUseCases {
+	return &AssignmentUseCases{
+		assignmentRepo: assignmentRepo,
+		quizRepo:       quizRepo,
+		historyRepo:    historyRepo,
+	}
+}
+
+type CreateAssignmentInput struct {
+	TeacherID string    `json:"-"`
+	QuizID    string    `json:"quizId"`
+	Title     string    `json:"title"`    // Padrão: título do quiz
+	OpensAt   time.Time `json:"opensAt"`  // Padrão: agora
+	ClosesAt  time.Time `json:"closesAt"` // Prazo de entrega
+}
+
+// CreateAssignment passa um quiz publicado como tarefa, com um código para os alunos.
+func (uc *AssignmentUseCases) CreateAssignment(ctx context.Context, input CreateAssignmentInput) (*assignment.Assignment, error) {
+	q, err := uc.quizRepo.FindByID(ctx, input.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, ErrQuizNaoEncontrado
+	}
+	if q.TeacherID != input.TeacherID {
+		return nil, ErrNaoAutorizado
+	}
+
+	code, err := uc.newAssignmentCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := assignment.NewAssignment(input.TeacherID, q, input.Title, code, input.OpensAt, input.ClosesAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.assignmentRepo.Save(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// newAssignmentCode sorteia um código que nenhuma outra tarefa use.
+func (uc *AssignmentUseCases) newAssignmentCode(ctx context.Context) (string, error) {
+	return newJoinCode(func(code string) (bool, error) {
+		existing, err := uc.assignmentRepo.FindByCode(ctx, code)
+		return existing != nil, err
+	})
+}
+
+func (uc *AssignmentUseCases) ListAssignments(ctx context.Context, teacherID string) ([]*assignment.Assignment, error) {
+	return uc.assignmentRepo.FindByTeacherID(ctx, teacherID)
+}
+
+func (uc *AssignmentUseCases) GetAssignment(ctx context.Context, id, teacherID string) (*assignment.Assignment, error) {
+	a, err := uc.assignmentRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, ErrTarefaNaoEncontrada
+	}
+	if a.TeacherID != teacherID {
+		return nil, ErrNaoAutorizado
+	}
+	return a, nil
+}
+
+// GetReport monta o relatório parcial (ou final) da tarefa no mesmo formato do histórico das salas.
+func (uc *AssignmentUseCases) GetReport(ctx context.Context, id, teacherID string) (*history.RoomHistory, error) {
+	a, err := uc.GetAssignment(ctx, id, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.buildHistory(ctx, a, time.Now())
+}
+
+// CloseAssignment encerra a tarefa (se ainda estiver aberta) e arquiva o resultado em rooms_history,
+// onde aparece junto das salas ao vivo. Arquiva uma única vez.
+func (uc *AssignmentUseCases) CloseAssignment(ctx context.Context, id, teacherID string) (*assignment.Assignment, error) {
+	a, err := uc.GetAssignment(ctx, id, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if a.HistoryID != "" {
+		return a, nil
+	}
+
+	archived, err := uc.archive(ctx, a, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !archived {
+		// Arquivada por outra chamada (ou pela varredura) no meio do caminho
+		return uc.GetAssignment(ctx, id, teacherID)
+	}
+	return a, nil
+}
+
+// Run arquiva periodicamente as tarefas cujo prazo terminou, até o contexto ser cancelado.
+func (uc *AssignmentUseCases) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			uc.ArchiveExpired(ctx, now)
+		}
+	}
+}
+
+// ArchiveExpired grava no histórico as tarefas que chegaram ao prazo sem serem encerradas
+// pelo professor, para que apareçam nos relatórios.
+func (uc *AssignmentUseCases) ArchiveExpired(ctx context.Context, now time.Time) {
+	assignments, err := uc.assignmentRepo.FindUnarchived(ctx)
+	if err != nil {
+		logger.Error("Falha ao listar tarefas para arquivamento", "erro", err)
+		return
+	}
+
+	for _, a := range assignments {
+		if a.Status(now) != assignment.StatusEncerrada {
+			continue
+		}
+		if _, err := uc.archive(ctx, a, now); err != nil {
+			// A tarefa continua sem historyId e será tentada na próxima varredura
+			logger.Error("Falha ao arquivar tarefa encerrada", "tarefa", a.ID, "erro", err)
+			continue
+		}
+		logger.Info("Tarefa encerrada arquivada", "tarefa", a.ID)
+	}
+}
+
+// archive encerra a tarefa em `now` e grava o histórico. A tarefa é reservada com um UPDATE
+// condicional antes da gravação; retorna false se outra chamada já a tinha arquivado.
+func (uc *AssignmentUseCases) archive(ctx context.Context, a *assignment.Assignment, now time.Time) (bool, error) {
+	a.Close(now)
+	h, err := uc.buildHistory(ctx, a, now)
+	if err != nil {
+		return false, err
+	}
+
+	a.HistoryID = h.ID
+	claimed, err := uc.assignmentRepo.ClaimArchive(ctx, a)
+	if err != nil || !claimed {
+		a.HistoryID = ""
+		return false, err
+	}
+
+	if err := uc.historyRepo.SaveHistory(ctx, h); err != nil {
+		if releaseErr := uc.assignmentRepo.ReleaseArchive(ctx, a.ID, h.ID); releaseErr != nil {
+			logger.Error("Falha ao liberar o arquivamento da tarefa", "tarefa", a.ID, "erro", releaseErr)
+		}
+		a.HistoryID = ""
+		return false, err
+	}
+	return true, nil
+}
+
+// buildHistory converte as tentativas da tarefa em RoomHistory (RoomID = ID da tarefa,
+// PlayerRuntimeID = ID da tentativa). Só entram as respostas já enviadas.
+func (uc *AssignmentUseCases) buildHistory(ctx context.Context, a *assignment.Assignment, now time.Time) (*history.RoomHistory, error) {
+	q, err := uc.quizRepo.FindByID(ctx, a.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, ErrQuizNaoEncontrado
+	}
+	attempts, err := uc.assignmentRepo.FindAttemptsByAssignmentID(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ranking: maior pontuação primeiro, empate pela tentativa iniciada antes
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if attempts[i].Score != attempts[j].Score {
+			return attempts[i].Score > attempts[j].Score
+		}
+		return attempts[i].StartedAt.Before(attempts[j].StartedAt)
+	})
+
+	status := a.Status(now)
+	h := &history.RoomHistory{
+		ID:                uuid.NewString(),
+		RoomID:            a.ID,
+		TeacherID:         a.TeacherID,
+		QuizID:            q.ID,
+		QuizTitleSnapshot: q.Title,
+		Status:            status,
+		TotalQuestions:    len(q.Questions),
+		StartedAt:         a.OpensAt,
+		FinishedAt:        a.ClosesAt,
+		CreatedAt:         now,
+	}
+
+	for i, question := range q.Questions {
+		h.Questions = append(h.Questions, history.QuestionStats{
+			ID:             uuid.NewString(),
+			RoomHistoryID:  h.ID,
+			QuestionIndex:  i,
+			QuestionID:     question.ID,
+			PromptSnapshot: question.Prompt,
+			QuestionType:   question.Type,
+			CorrectIndexes: question.CorrectIndexes,
+			OptionCounts:   make([]int, len(question.Options)),
+		})
+	}
+
+	for _, t := range attempts {
+		hP := history.PlayerStats{
+			ID:              uuid.NewString(),
+			RoomHistoryID:   h.ID,
+			PlayerRuntimeID: t.ID,
+			Nickname:        t.Nickname,
+			Score:           t.Score,
+		}
+
+		answered := make(map[int]bool, len(t.Answers))
+		for _, ans := range t.Answers {
+			if ans.QuestionIndex >= len(q.Questions) {
+				continue
+			}
+			answered[ans.QuestionIndex] = true
+			qs := &h.Questions[ans.QuestionIndex]
+			for _, idx := range ans.Response.Indexes {
+				if idx >= 0 && idx < len(qs.OptionCounts) {
+					qs.OptionCounts[idx]++
+				}
+			}
+			if q.Questions[ans.QuestionIndex].IsScored() {
+				if ans.Correct {
+					qs.CorrectCount++
+					hP.CorrectCount++
+				} else {
+					hP.WrongCount++
+				}
+			}
+
+			selected := ans.Response.Indexes
+			if selected == nil {
+				selected = []int{}
+			}
+			h.Answers = append(h.Answers, history.PlayerAnswer{
+				ID:              uuid.NewString(),
+				RoomHistoryID:   h.ID,
+				QuestionIndex:   ans.QuestionIndex,
+				RoomPlayerID:    hP.ID,
+				SelectedIndexes: selected,
+				AnswerText:      ans.Response.Text,
+				Order:           ans.Response.Order,
+				Pairs:           ans.Response.Pairs,
+				IsCorrect:       ans.Correct,
+			})
+		}
+
+		// Com o prazo encerrado, perguntas pontuadas sem resposta contam como erro
+		if status == assignment.StatusEncerrada {
+			for i, question := range q.Questions {
+				if question.IsScored() && !answered[i] {
+					hP.WrongCount++
+				}
+			}
+		}
+		h.Players = append(h.Players, hP)
+	}
+	return h, nil
+}
+
+// ------ HOMEWORK METHODS (Aluno, via código da tarefa e token da tentativa) ------
+
+// HomeworkInfo é o resumo público da tarefa, exibido antes de o aluno começar.
+type HomeworkInfo struct {
+	Code           string    `json:"code"`
+	Title          string    `json:"title"`
+	QuizTitle      string    `json:"quizTitle"`
+	TotalQuestions int       `json:"totalQuestions"`
+	OpensAt        time.Time `json:"opensAt"`
+	ClosesAt       time.Time `json:"closesAt"`
+	Status         string    `json:"status"` // SCHEDULED | OPEN | CLOSED
+}
+
+// StartAttemptOutput devolve o token que o aluno usa nas próximas chamadas.
+type StartAttemptOutput struct {
+	AttemptToken string              `json:"attemptToken"`
+	Attempt      *assignment.Attempt `json:"attempt"`
+}
+
+// HomeworkQuestion é a pergunta atual da tentativa (sem gabarito). Question é nil quando
+// a tentativa terminou.
+type HomeworkQuestion struct {
+	QuestionIndex  int            `json:"questionIndex"`
+	TotalQuestions int            `json:"totalQuestions"`
+	Question       *quiz.Question `json:"question,omitempty"`
+	Score          int            `json:"score"`
+	Finished       bool           `json:"finished"`
+}
+
+// HomeworkAnswerResult é a correção imediata de uma resposta. Não traz o gabarito: com a tarefa
+// aberta, ele poderia ser repassado aos colegas (ver AttemptReview).
+type HomeworkAnswerResult struct {
+	QuestionIndex int     `json:"questionIndex"`
+	Correct       bool    `json:"correct"`
+	Credit        float64 `json:"credit"`
+	Points        int     `json:"points"`
+	Score         int     `json:"score"`
+	Finished      bool    `json:"finished"`
+}
+
+// AttemptReview é o progresso da tentativa. O gabarito só é incluído depois que a tarefa é
+// encerrada (prazo ou professor), para todos os alunos ao mesmo tempo.
+type AttemptReview struct {
+	*assignment.Attempt
+	AnswerKey []AnswerKey `json:"answerKey,omitempty"`
+}
+
+// AnswerKey são as alternativas corretas de uma pergunta de alternativas.
+type AnswerKey struct {
+	QuestionIndex  int   `json:"questionIndex"`
+	CorrectIndexes []int `json:"correctIndexes"`
+}
+
+// GetHomework busca a tarefa pelo código compartilhado com os alunos.
+func (uc *AssignmentUseCases) GetHomework(ctx context.Context, code string) (*HomeworkInfo, error) {
+	a, q, err := uc.findByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return &HomeworkInfo{
+		Code:           a.Code,
+		Title:          a.Title,
+		QuizTitle:      q.Title,
+		TotalQuestions: len(q.Questions),
+		OpensAt:        a.OpensAt,
+		ClosesAt:       a.ClosesAt,
+		Status:         a.Status(time.Now()),
+	}, nil
+}
+
+// StartAttempt inicia a tentativa do aluno. O apelido é único por tarefa.
+func (uc *AssignmentUseCases) StartAttempt(ctx context.Context, code, nickname string) (*StartAttemptOutput, error) {
+	a, q, err := uc.findByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := assignment.NewAttempt(a, q, nickname, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.assignmentRepo.FindAttemptsByAssignmentID(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Nickname == t.Nickname {
+			return nil, ErrApelidoEmUsoTarefa
+		}
+	}
+
+	if err := uc.assignmentRepo.SaveAttempt(ctx, t); err != nil {
+		return nil, err
+	}
+	return &StartAttemptOutput{AttemptToken: t.Token, Attempt: t}, nil
+}
+
+// GetAttempt retorna o progresso e as respostas da tentativa, com o gabarito se a tarefa já encerrou.
+func (uc *AssignmentUseCases) GetAttempt(ctx context.Context, token string) (*AttemptReview, error) {
+	t, a, q, err := uc.loadAttempt(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &AttemptReview{Attempt: t}
+	if a.Status(time.Now()) == assignment.StatusEncerrada {
+		for i, question := range q.Questions {
+			if question.HasOptions() && question.IsScored() {
+				review.AnswerKey = append(review.AnswerKey, AnswerKey{QuestionIndex: i, CorrectIndexes: question.CorrectIndexes})
+			}
+		}
+	}
+	return review, nil
+}
+
+// NextQuestion retorna a pergunta que o aluno deve responder agora.
+func (uc *AssignmentUseCases) NextQuestion(ctx context.Context, token string) (*HomeworkQuestion, error) {
+	t, a, q, err := uc.loadAttempt(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &HomeworkQuestion{
+		QuestionIndex:  t.CurrentIndex,
+		TotalQuestions: len(q.Questions),
+		Score:          t.Score,
+		Finished:       t.Finished(),
+	}
+	if question, ok := t.CurrentQuestion(q); ok {
+		if err := a.CheckOpen(time.Now()); err != nil {
+			return nil, err
+		}
+		out.Question = &question
+	}
+	return out, nil
+}
+
+// SubmitAnswer corrige e grava a resposta da pergunta atual.
+func (uc *AssignmentUseCases) SubmitAnswer(ctx context.Context, token string, questionIndex int, resp quiz.Response) (*HomeworkAnswerResult, error) {
+	t, a, q, err := uc.loadAttempt(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := a.CheckOpen(now); err != nil {
+		return nil, err
+	}
+	ans, err := t.Answer(q, questionIndex, resp, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.assignmentRepo.SaveAnswer(ctx, t, ans); err != nil {
+		return nil, err
+	}
+
+	out := &HomeworkAnswerResult{
+		QuestionIndex: ans.QuestionIndex,
+		Correct:       ans.Correct,
+		Credit:        ans.Credit,
+		Points:        ans.Points,
+		Score:         t.Score,
+		Finished:      t.Finished(),
+	}
+	return out, nil
+}
+
+// findByCode busca a tarefa e o quiz pelo código público.
+func (uc *AssignmentUseCases) findByCode(ctx context.Context, code string) (*assignment.Assignment, *quiz.Quiz, error) {
+	a, err := uc.assignmentRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil {
+		return nil, nil, ErrTarefaNaoEncontrada
+	}
+	q, err := uc.quizRepo.FindByID(ctx, a.QuizID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if q == nil {
+		return nil, nil, ErrQuizNaoEncontrado
+	}
+	return a, q, nil
+}
+
+// loadAttempt busca a tentativa pelo token, junto da tarefa e do quiz.
+func (uc *AssignmentUseCases) loadAttempt(ctx context.Context, token string) (*assignment.Attempt, *assignment.Assignment, *quiz.Quiz, error) {
+	t, err := uc.assignmentRepo.FindAttemptByToken(ctx, token)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if t == nil {
+		return nil, nil, nil, ErrTentativaNaoEncontrada
+	}
+	a, err := uc.assignmentRepo.FindByID(ctx, t.AssignmentID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if a == nil {
+		return nil, nil, nil, ErrTarefaNaoEncontrada
+	}
+	q, err := uc.quizRepo.FindByID(ctx, a.QuizID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if q == nil {
+		return nil, nil, nil, ErrQuizNaoEncontrado
+	}
+	return t, a, q, nil
+}
@@ -0,0 +1,514 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"rankit/internal/domain/assignment"
+	"rankit/internal/domain/history"
+	"rankit/internal/domain/quiz"
+	"rankit/internal/domain/scoring"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeQuizRepo guarda os quizzes em memória (só a leitura é usada pelas tarefas).
+type fakeQuizRepo struct {
+	quizzes map[string]*quiz.Quiz
+}
+
+func (r *fakeQuizRepo) Save(ctx context.Context, q *quiz.Quiz) error { return nil }
+func (r *fakeQuizRepo) FindByID(ctx context.Context, id string) (*quiz.Quiz, error) {
+	return r.quizzes[id], nil
+}
+func (r *fakeQuizRepo) FindByTeacherID(ctx context.Context, teacherID string) ([]*quiz.Quiz, error) {
+	return nil, nil
+}
+func (r *fakeQuizRepo) Delete(ctx context.Context, id string) error    { return nil }
+func (r *fakeQuizRepo) Update(ctx context.Context, q *quiz.Quiz) error { return nil }
+
+// fakeAssignmentRepo imita o repositório SQLite: grava e devolve cópias, e ClaimArchive
+// só reserva a tarefa se o historyId gravado ainda estiver vazio.
+type fakeAssignmentRepo struct {
+	mu          sync.Mutex
+	assignments map[string]assignment.Assignment
+	attempts    map[string]assignment.Attempt
+}
+
+func newFakeAssignmentRepo() *fakeAssignmentRepo {
+	return &fakeAssignmentRepo{
+		assignments: make(map[string]assignment.Assignment),
+		attempts:    make(map[string]assignment.Attempt),
+	}
+}
+
+func (r *fakeAssignmentRepo) Save(ctx context.Context, a *assignment.Assignment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assignments[a.ID] = *a
+	return nil
+}
+
+func (r *fakeAssignmentRepo) Update(ctx context.Context, a *assignment.Assignment) error {
+	return r.Save(ctx, a)
+}
+
+func (r *fakeAssignmentRepo) FindByID(ctx context.Context, id string) (*assignment.Assignment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.assignments[id]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+func (r *fakeAssignmentRepo) FindByCode(ctx context.Context, code string) (*assignment.Assignment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.assignments {
+		if a.Code == code {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAssignmentRepo) FindByTeacherID(ctx context.Context, teacherID string) ([]*assignment.Assignment, error) {
+	return r.filter(func(a assignment.Assignment) bool { return a.TeacherID == teacherID }), nil
+}
+
+func (r *fakeAssignmentRepo) FindUnarchived(ctx context.Context) ([]*assignment.Assignment, error) {
+	return r.filter(func(a assignment.Assignment) bool { return a.HistoryID == "" }), nil
+}
+
+func (r *fakeAssignmentRepo) filter(keep func(assignment.Assignment) bool) []*assignment.Assignment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*assignment.Assignment
+	for _, a := range r.assignments {
+		if keep(a) {
+			cp := a
+			out = append(out, &cp)
+		}
+	}
+	return out
+}
+
+func (r *fakeAssignmentRepo) ClaimArchive(ctx context.Context, a *assignment.Assignment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.assignments[a.ID]
+	if stored.HistoryID != "" {
+		return false, nil
+	}
+	stored.OpensAt, stored.ClosesAt, stored.HistoryID = a.OpensAt, a.ClosesAt, a.HistoryID
+	r.assignments[a.ID] = stored
+	return true, nil
+}
+
+func (r *fakeAssignmentRepo) ReleaseArchive(ctx context.Context, id, historyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored := r.assignments[id]; stored.HistoryID == historyID {
+		stored.HistoryID = ""
+		r.assignments[id] = stored
+	}
+	return nil
+}
+
+func (r *fakeAssignmentRepo) SaveAttempt(ctx context.Context, t *assignment.Attempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts[t.ID] = copyAttempt(*t)
+	return nil
+}
+
+func (r *fakeAssignmentRepo) FindAttemptByToken(ctx context.Context, token string) (*assignment.Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.attempts {
+		if t.Token == token {
+			cp := copyAttempt(t)
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAssignmentRepo) FindAttemptsByAssignmentID(ctx context.Context, assignmentID string) ([]*assignment.Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*assignment.Attempt
+	for _, t := range r.attempts {
+		if t.AssignmentID == assignmentID {
+			cp := copyAttempt(t)
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeAssignmentRepo) SaveAnswer(ctx context.Context, t *assignment.Attempt, ans *assignment.AttemptAnswer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.attempts[t.ID]
+	stored.CurrentIndex, stored.Score, stored.Shuffle, stored.FinishedAt = t.CurrentIndex, t.Score, t.Shuffle, t.FinishedAt
+	stored.Answers = append(stored.Answers, *ans)
+	r.attempts[t.ID] = stored
+	return nil
+}
+
+// copyAttempt copia a tentativa sem compartilhar as respostas com quem chamou.
+func copyAttempt(t assignment.Attempt) assignment.Attempt {
+	t.Answers = append([]assignment.AttemptAnswer(nil), t.Answers...)
+	return t
+}
+
+// fakeHistoryRepo guarda os históricos gravados; `err` simula falha na gravação.
+type fakeHistoryRepo struct {
+	mu    sync.Mutex
+	saved []*history.RoomHistory
+	err   error
+}
+
+func (r *fakeHistoryRepo) SaveHistory(ctx context.Context, h *history.RoomHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.saved = append(r.saved, h)
+	return nil
+}
+func (r *fakeHistoryRepo) ListByTeacherID(ctx context.Context, teacherID string, limit, offset int) ([]*history.RoomHistory, error) {
+	return nil, nil
+}
+func (r *fakeHistoryRepo) GetByID(ctx context.Context, id string) (*history.RoomHistory, error) {
+	return nil, nil
+}
+func (r *fakeHistoryRepo) GetQuizStats(ctx context.Context, quizID string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (r *fakeHistoryRepo) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.saved)
+}
+
+// homeworkFixture reúne os casos de uso de tarefas com repositórios em memória.
+type homeworkFixture struct {
+	uc          *AssignmentUseCases
+	assignments *fakeAssignmentRepo
+	histories   *fakeHistoryRepo
+}
+
+// newHomeworkFixture cria um quiz publicado com uma múltipla escolha (correta: 1),
+// um verdadeiro ou falso (correta: 0) e uma enquete.
+func newHomeworkFixture() *homeworkFixture {
+	q := &quiz.Quiz{
+		ID:        "quiz-1",
+		TeacherID: "teacher-1",
+		Title:     "Frações",
+		Status:    quiz.StatusPublicado,
+		Questions: []quiz.Question{
+			{ID: "q1", Type: quiz.TipoMultiplaEscolha, Prompt: "1/2 + 1/2", Options: []string{"0", "1", "2"}, CorrectIndexes: []int{1}},
+			{ID: "q2", Type: quiz.TipoVerdadeiroFalso, Prompt: "1/3 < 1/2", Options: []string{"Verdadeiro", "Falso"}, CorrectIndexes: []int{0}},
+			{ID: "q3", Type: quiz.TipoEnquete, Prompt: "Gostou?", Options: []string{"Sim", "Não"}},
+		},
+	}
+	f := &homeworkFixture{assignments: newFakeAssignmentRepo(), histories: &fakeHistoryRepo{}}
+	f.uc = NewAssignmentUseCases(f.assignments, &fakeQuizRepo{quizzes: map[string]*quiz.Quiz{q.ID: q}}, f.histories)
+	return f
+}
+
+// addAssignment grava uma tarefa com o período informado, relativo ao instante atual.
+func (f *homeworkFixture) addAssignment(t *testing.T, code string, opensIn, closesIn time.Duration) *assignment.Assignment {
+	t.Helper()
+	now := time.Now()
+	a := &assignment.Assignment{
+		ID:        "assignment-" + code,
+		TeacherID: "teacher-1",
+		QuizID:    "quiz-1",
+		Title:     "Lição",
+		Code:      code,
+		OpensAt:   now.Add(opensIn),
+		ClosesAt:  now.Add(closesIn),
+		CreatedAt: now,
+	}
+	if err := f.assignments.Save(context.Background(), a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return a
+}
+
+// start inicia a tentativa do aluno e devolve o token.
+func (f *homeworkFixture) start(t *testing.T, code, nickname string) string {
+	t.Helper()
+	out, err := f.uc.StartAttempt(context.Background(), code, nickname)
+	if err != nil {
+		t.Fatalf("StartAttempt(%s): %v", nickname, err)
+	}
+	return out.AttemptToken
+}
+
+// answer responde a pergunta `index` com uma alternativa.
+func (f *homeworkFixture) answer(t *testing.T, token string, index, option int) *HomeworkAnswerResult {
+	t.Helper()
+	res, err := f.uc.SubmitAnswer(context.Background(), token, index, quiz.Response{Indexes: []int{option}})
+	if err != nil {
+		t.Fatalf("SubmitAnswer(%d): %v", index, err)
+	}
+	return res
+}
+
+func TestStartAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		nickname string
+		wantErr  error
+	}{
+		{name: "tarefa aberta", code: "100001", nickname: "Bia"},
+		{name: "apelido com espaços já usado", code: "100001", nickname: "  Ana ", wantErr: ErrApelidoEmUsoTarefa},
+		{name: "apelido vazio", code: "100001", nickname: "   ", wantErr: assignment.ErrApelidoObrigatorio},
+		{name: "tarefa agendada", code: "100002", nickname: "Bia", wantErr: assignment.ErrTarefaNaoIniciada},
+		{name: "prazo encerrado", code: "100003", nickname: "Bia", wantErr: assignment.ErrTarefaEncerrada},
+		{name: "código inexistente", code: "999999", nickname: "Bia", wantErr: ErrTarefaNaoEncontrada},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newHomeworkFixture()
+			f.addAssignment(t, "100001", -time.Hour, time.Hour)
+			f.addAssignment(t, "100002", time.Hour, 2*time.Hour)
+			f.addAssignment(t, "100003", -2*time.Hour, -time.Hour)
+			f.start(t, "100001", "Ana")
+
+			out, err := f.uc.StartAttempt(context.Background(), tt.code, tt.nickname)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartAttempt = %v, esperado %v", err, tt.wantErr)
+			}
+			if err == nil && (out.AttemptToken == "" || out.Attempt.CurrentIndex != 0) {
+				t.Fatalf("tentativa iniciada = %+v", out)
+			}
+		})
+	}
+}
+
+func TestHomeworkAnswerOrder(t *testing.T) {
+	f := newHomeworkFixture()
+	a := f.addAssignment(t, "100001", -time.Hour, time.Hour)
+	token := f.start(t, "100001", "Ana")
+	ctx := context.Background()
+
+	steps := []struct {
+		name    string
+		index   int
+		option  int
+		wantErr error
+	}{
+		{name: "pular para a segunda", index: 1, option: 0, wantErr: assignment.ErrPerguntaForaDeOrdem},
+		{name: "primeira pergunta", index: 0, option: 1},
+		{name: "responder de novo a primeira", index: 0, option: 0, wantErr: assignment.ErrPerguntaForaDeOrdem},
+		{name: "alternativa inexistente", index: 1, option: 5, wantErr: quiz.ErrRespostaInvalida},
+		{name: "segunda pergunta", index: 1, option: 1},
+		{name: "enquete", index: 2, option: 0},
+		{name: "depois de terminar", index: 3, option: 0, wantErr: assignment.ErrTentativaConcluida},
+	}
+	for _, st := range steps {
+		_, err := f.uc.SubmitAnswer(ctx, token, st.index, quiz.Response{Indexes: []int{st.option}})
+		if !errors.Is(err, st.wantErr) {
+			t.Fatalf("%s: SubmitAnswer = %v, esperado %v", st.name, err, st.wantErr)
+		}
+	}
+
+	// Só a primeira valeu pontos: a segunda errou e a enquete não pontua
+	review, err := f.uc.GetAttempt(ctx, token)
+	if err != nil {
+		t.Fatalf("GetAttempt: %v", err)
+	}
+	if review.Score != scoring.PontosFixos || len(review.Answers) != 3 || !review.Finished() {
+		t.Fatalf("tentativa = %+v, esperado %d pontos e 3 respostas", review.Attempt, scoring.PontosFixos)
+	}
+
+	// Com o prazo encerrado, nenhuma resposta é aceita
+	other := f.start(t, "100001", "Bia")
+	a.ClosesAt = time.Now().Add(-time.Second)
+	f.assignments.Update(ctx, a)
+	if _, err := f.uc.SubmitAnswer(ctx, other, 0, quiz.Response{Indexes: []int{1}}); !errors.Is(err, assignment.ErrTarefaEncerrada) {
+		t.Fatalf("resposta após o prazo = %v", err)
+	}
+}
+
+func TestGetAttemptWithholdsAnswerKey(t *testing.T) {
+	f := newHomeworkFixture()
+	a := f.addAssignment(t, "100001", -time.Hour, time.Hour)
+	token := f.start(t, "100001", "Ana")
+	ctx := context.Background()
+	f.answer(t, token, 0, 0)
+
+	review, err := f.uc.GetAttempt(ctx, token)
+	if err != nil {
+		t.Fatalf("GetAttempt: %v", err)
+	}
+	if review.AnswerKey != nil {
+		t.Fatalf("gabarito liberado com a tarefa aberta: %+v", review.AnswerKey)
+	}
+
+	if _, err := f.uc.CloseAssignment(ctx, a.ID, "teacher-1"); err != nil {
+		t.Fatalf("CloseAssignment: %v", err)
+	}
+	review, err = f.uc.GetAttempt(ctx, token)
+	if err != nil {
+		t.Fatalf("GetAttempt: %v", err)
+	}
+	// Só as perguntas pontuadas com alternativas: a enquete não tem gabarito
+	want := []AnswerKey{{QuestionIndex: 0, CorrectIndexes: []int{1}}, {QuestionIndex: 1, CorrectIndexes: []int{0}}}
+	if len(review.AnswerKey) != len(want) {
+		t.Fatalf("gabarito = %+v, esperado %+v", review.AnswerKey, want)
+	}
+	for i, k := range want {
+		got := review.AnswerKey[i]
+		if got.QuestionIndex != k.QuestionIndex || len(got.CorrectIndexes) != 1 || got.CorrectIndexes[0] != k.CorrectIndexes[0] {
+			t.Fatalf("gabarito[%d] = %+v, esperado %+v", i, got, k)
+		}
+	}
+}
+
+func TestAssignmentArchivedOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("professor encerra duas vezes e a varredura passa depois", func(t *testing.T) {
+		f := newHomeworkFixture()
+		a := f.addAssignment(t, "100001", -time.Hour, time.Hour)
+
+		first, err := f.uc.CloseAssignment(ctx, a.ID, "teacher-1")
+		if err != nil {
+			t.Fatalf("CloseAssignment: %v", err)
+		}
+		second, err := f.uc.CloseAssignment(ctx, a.ID, "teacher-1")
+		if err != nil {
+			t.Fatalf("CloseAssignment de novo: %v", err)
+		}
+		f.uc.ArchiveExpired(ctx, time.Now())
+
+		if f.histories.count() != 1 || first.HistoryID == "" || second.HistoryID != first.HistoryID {
+			t.Fatalf("históricos = %d, historyIds %q e %q", f.histories.count(), first.HistoryID, second.HistoryID)
+		}
+	})
+
+	t.Run("encerramento e varredura ao mesmo tempo", func(t *testing.T) {
+		f := newHomeworkFixture()
+		a := f.addAssignment(t, "100001", -2*time.Hour, -time.Hour)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := f.uc.CloseAssignment(ctx, a.ID, "teacher-1"); err != nil {
+					t.Errorf("CloseAssignment: %v", err)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				f.uc.ArchiveExpired(ctx, time.Now())
+			}()
+		}
+		wg.Wait()
+
+		if n := f.histories.count(); n != 1 {
+			t.Fatalf("históricos gravados = %d, esperado 1", n)
+		}
+	})
+
+	t.Run("falha ao gravar o histórico libera a reserva", func(t *testing.T) {
+		f := newHomeworkFixture()
+		a := f.addAssignment(t, "100001", -2*time.Hour, -time.Hour)
+
+		f.histories.err = errors.New("disco cheio")
+		f.uc.ArchiveExpired(ctx, time.Now())
+		if stored, _ := f.assignments.FindByID(ctx, a.ID); stored.HistoryID != "" {
+			t.Fatalf("historyId = %q após a falha, esperado vazio", stored.HistoryID)
+		}
+
+		// A próxima varredura tenta de novo
+		f.histories.err = nil
+		f.uc.ArchiveExpired(ctx, time.Now())
+		stored, _ := f.assignments.FindByID(ctx, a.ID)
+		if f.histories.count() != 1 || stored.HistoryID != f.histories.saved[0].ID {
+			t.Fatalf("históricos = %d, historyId = %q", f.histories.count(), stored.HistoryID)
+		}
+	})
+
+	t.Run("tarefa aberta não é arquivada pela varredura", func(t *testing.T) {
+		f := newHomeworkFixture()
+		f.addAssignment(t, "100001", -time.Hour, time.Hour)
+
+		f.uc.ArchiveExpired(ctx, time.Now())
+		if n := f.histories.count(); n != 0 {
+			t.Fatalf("históricos gravados = %d, esperado 0", n)
+		}
+	})
+}
+
+func TestAssignmentReport(t *testing.T) {
+	ctx := context.Background()
+	f := newHomeworkFixture()
+	a := f.addAssignment(t, "100001", -time.Hour, time.Hour)
+
+	ana := f.start(t, "100001", "Ana")
+	f.answer(t, ana, 0, 1) // Acerta
+	f.answer(t, ana, 1, 0) // Acerta
+	bia := f.start(t, "100001", "Bia")
+	f.answer(t, bia, 0, 2) // Erra e para
+
+	tests := []struct {
+		name string
+		// closed encerra a tarefa antes de gerar o relatório
+		closed    bool
+		wantWrong map[string]int
+	}{
+		// Com a tarefa aberta, a pergunta ainda não respondida não conta como erro
+		{name: "relatório parcial", closed: false, wantWrong: map[string]int{"Ana": 0, "Bia": 1}},
+		{name: "relatório final", closed: true, wantWrong: map[string]int{"Ana": 0, "Bia": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.closed {
+				if _, err := f.uc.CloseAssignment(ctx, a.ID, "teacher-1"); err != nil {
+					t.Fatalf("CloseAssignment: %v", err)
+				}
+			}
+			report, err := f.uc.GetReport(ctx, a.ID, "teacher-1")
+			if err != nil {
+				t.Fatalf("GetReport: %v", err)
+			}
+
+			if len(report.Players) != 2 || report.Players[0].Nickname != "Ana" || report.Players[1].Nickname != "Bia" {
+				t.Fatalf("ranking = %+v, esperado Ana e depois Bia", report.Players)
+			}
+			if got := report.Players[0].Score; got != 2*scoring.PontosFixos {
+				t.Fatalf("pontuação de Ana = %d, esperado %d", got, 2*scoring.PontosFixos)
+			}
+			for _, p := range report.Players {
+				if p.WrongCount != tt.wantWrong[p.Nickname] {
+					t.Fatalf("%s: erros = %d, esperado %d", p.Nickname, p.WrongCount, tt.wantWrong[p.Nickname])
+				}
+			}
+			if got := report.Questions[0].OptionCounts; got[1] != 1 || got[2] != 1 {
+				t.Fatalf("votos da primeira pergunta = %v", got)
+			}
+			if report.Questions[0].CorrectCount != 1 || len(report.Answers) != 3 {
+				t.Fatalf("acertos = %d, respostas = %d", report.Questions[0].CorrectCount, len(report.Answers))
+			}
+		})
+	}
+
+	if _, err := f.uc.GetReport(ctx, a.ID, "outro-professor"); !errors.Is(err, ErrNaoAutorizado) {
+		t.Fatalf("relatório de outro professor = %v", err)
+	}
+}
@@ -3,18 +3,35 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 	"rankit/internal/domain/game"
 	"rankit/internal/domain/quiz"
+	"rankit/internal/infra/logger"
 	"rankit/internal/ports"
+	"sync"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrSalaNaoEncontrada = errors.New("sala não encontrada")
+	ErrArquivamento      = errors.New("o jogo terminou, mas não foi possível salvar o histórico")
+)
+
+// tamanhoPodio é o número de colocados enviados no evento game_finished.
+const tamanhoPodio = 3
+
+// tamanhoPlacarTela é o número de colocados exibidos na tela de projeção.
+const tamanhoPlacarTela = 5
+
 type GameUseCases struct {
-	gameRepo  ports.GameRepository
-	quizRepo  ports.QuizRepository
-	hub       ports.RealTimeHub
-	historyUC *HistoryUseCases
+	gameRepo     ports.GameRepository
+	quizRepo     ports.QuizRepository
+	hub          ports.RealTimeHub
+	historyUC    *HistoryUseCases
+	rejoinTokens ports.RejoinTokenService
+	lifecycle    *RoomLifecycleManager
+	codeMu       sync.Mutex // Serializa sorteio + gravação do código da sala (evita códigos duplicados)
 }
 
 func NewGameUseCases(
@@ -22,17 +39,25 @@ func NewGameUseCases(
 	quizRepo ports.QuizRepository,
 	hub ports.RealTimeHub,
 	historyUC *HistoryUseCases,
+	rejoinTokens ports.RejoinTokenService,
+	lifecycle *RoomLifecycleManager,
 ) *GameUseCases {
 	return &GameUseCases{
-		gameRepo:  gameRepo,
-		quizRepo:  quizRepo,
-		hub:       hub,
-		historyUC: historyUC,
+		gameRepo:     gameRepo,
+		quizRepo:     quizRepo,
+		hub:          hub,
+		historyUC:    historyUC,
+		rejoinTokens: rejoinTokens,
+		lifecycle:    lifecycle,
 	}
 }
 
 // CreateRoom cria uma sala a partir de um quiz PUBLISHED.
-func (uc *GameUseCases) CreateRoom(ctx context.Context, teacherID, quizID string) (*game.Room, error) {
+func (uc *GameUseCases) CreateRoom(ctx context.Context, teacherID, quizID string, settings game.RoomSettings) (*game.Room, error) {
+	if err := settings.Normalize(); err != nil {
+		return nil, err
+	}
+
 	q, err := uc.quizRepo.FindByID(ctx, quizID)
 	if err != nil {
 		return nil, err
@@ -47,18 +72,92 @@ func (uc *GameUseCases) CreateRoom(ctx context.Context, teacherID, quizID string
 		return nil, errors.New("apenas quizzes publicados podem ser jogados")
 	}
 
-	// Gera ID curto para a sala
-	roomID := uuid.NewString()[:6]
+	if settings.ShuffleQuestions {
+		q = game.ShuffleQuestions(q)
+	}
 
-	room := game.NewRoom(roomID, teacherID, q)
-	if err := uc.gameRepo.SaveRoom(room); err != nil {
+	uc.codeMu.Lock()
+	defer uc.codeMu.Unlock()
+
+	code, err := uc.newRoomCode()
+	if err != nil {
+		return nil, err
+	}
+
+	room := game.NewRoom(uuid.NewString(), code, teacherID, q, settings)
+	uc.attach(room)
+	if err := uc.gameRepo.AddRoom(room); err != nil {
 		return nil, err
 	}
 
 	return room, nil
 }
 
-// JoinRoom adiciona um aluno (solicita entrada).
+// newRoomCode sorteia um código que não esteja em uso por nenhuma sala ativa.
+// Códigos de salas encerradas ou expiradas voltam a ficar disponíveis.
+func (uc *GameUseCases) newRoomCode() (string, error) {
+	return newJoinCode(func(code string) (bool, error) {
+		existing, err := uc.gameRepo.FindRoomByCode(code)
+		return existing != nil, err
+	})
+}
+
+// findRoom busca a sala pelo ID interno ou pelo código de entrada.
+func (uc *GameUseCases) findRoom(idOrCode string) (*game.Room, error) {
+	room, err := uc.gameRepo.FindRoomByID(idOrCode)
+	if err != nil || room != nil {
+		return room, err
+	}
+	return uc.gameRepo.FindRoomByCode(idOrCode)
+}
+
+// ResolveRoomID converte ID ou código de entrada no ID interno da sala.
+func (uc *GameUseCases) ResolveRoomID(idOrCode string) (string, error) {
+	room, err := uc.findRoom(idOrCode)
+	if err != nil {
+		return "", err
+	}
+	if room == nil {
+		return "", ErrSalaNaoEncontrada
+	}
+	return room.ID, nil
+}
+
+// IsRoomTeacher indica se o professor é o dono da sala.
+func (uc *GameUseCases) IsRoomTeacher(roomID, teacherID string) bool {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	return err == nil && room != nil && room.TeacherID == teacherID
+}
+
+// attach liga o cronômetro da sala ao fluxo de revelação.
+func (uc *GameUseCases) attach(room *game.Room) {
+	// Tempo esgotado segue o mesmo fluxo da revelação feita pelo professor
+	room.OnDeadline(func() { uc.revealExpired(room.ID) })
+}
+
+// ResumeRooms religa cronômetros das salas restauradas do banco (chamado na inicialização).
+func (uc *GameUseCases) ResumeRooms() error {
+	rooms, err := uc.gameRepo.ListRooms()
+	if err != nil {
+		return err
+	}
+	for _, room := range rooms {
+		uc.attach(room)
+		room.RestartTimer()
+	}
+	return nil
+}
+
+// persist grava o estado atual da sala. Falhas são apenas registradas: o jogo continua em memória.
+// Salas já encerradas são ignoradas pelo repositório.
+func (uc *GameUseCases) persist(room *game.Room) {
+	if err := uc.gameRepo.SaveRoom(room); err != nil {
+		logger.Error("Erro ao salvar estado da sala", "roomId", room.ID, "error", err)
+	}
+}
+
+// JoinRoom adiciona um aluno (solicita entrada). Recusas com código (*game.Error) já são
+// enviadas ao aluno como join_rejected.
 func (uc *GameUseCases) JoinRoom(roomID, nickname, sessionID string) (*game.Player, error) {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
 	if err != nil {
@@ -68,28 +167,36 @@ func (uc *GameUseCases) JoinRoom(roomID, nickname, sessionID string) (*game.Play
 		return nil, errors.New("sala não encontrada")
 	}
 
-	// Tenta entrar (cai em pendente ou rejeita se iniciado)
+	// Tenta entrar (cai em pendente, é aprovado direto ou é recusado conforme as regras da sala)
 	player, err := room.JoinRequest(sessionID, nickname)
 	if err != nil {
+		var reason *game.Error
+		if errors.As(err, &reason) {
+			uc.sendJoinRejected(sessionID, reason)
+		}
 		return nil, err
 	}
+	uc.persist(room)
 
-	// Se o jogador JÁ estava em Players (reconectou), enviamos o estado e broadcast de volta
-	if _, approved := room.Players[sessionID]; approved {
-		uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
-			"type":    "player_joined",
-			"payload": player,
-		})
+	// Token para retomar o lugar na sala caso a conexão caia
+	uc.sendRejoinToken(roomID, sessionID)
+
+	// Se o jogador JÁ estava em Players (reconectou ou foi aprovado automaticamente),
+	// enviamos o estado e broadcast de volta
+	if room.IsPlayer(sessionID) {
+		// O próprio aluno recebe o room_state logo abaixo
+		uc.broadcastPlayers(roomID, sessionID, "player_joined", player, player.Public())
 		uc.hub.SendToPlayer(sessionID, map[string]interface{}{
 			"type":    "room_state",
-			"payload": room.GetStateSnapshot(),
+			"payload": room.GetPublicStateSnapshot(),
 		})
+		uc.sendPlayerOptions(sessionID, room.PlayerOptions(sessionID))
 		return player, nil
 	}
 
 	// Agora ele está em PENDING.
-	// 1. Notifica a sala (Professor deve filtrar por type)
-	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+	// 1. Notifica o professor (o connectionId dos candidatos não vai para os alunos)
+	uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, map[string]interface{}{
 		"type": "player_request_entry",
 		"payload": map[string]string{
 			"nickname":     nickname,
@@ -121,27 +228,25 @@ func (uc *GameUseCases) ModerateEntry(roomID, teacherID, targetConnectionID, act
 		if err != nil {
 			return err
 		}
+		uc.persist(room)
 
-		// Notifica sucesso: Player entrou de fato
-		uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
-			"type":    "player_joined",
-			"payload": player,
-		})
+		// Notifica sucesso: Player entrou de fato (o aluno liberado recebe o room_state)
+		uc.broadcastPlayers(roomID, targetConnectionID, "player_joined", player, player.Public())
 		// Envia estado para o aluno liberado
 		uc.hub.SendToPlayer(targetConnectionID, map[string]interface{}{
 			"type":    "room_state",
-			"payload": room.GetStateSnapshot(),
+			"payload": room.GetPublicStateSnapshot(),
 		})
+		uc.sendPlayerOptions(targetConnectionID, room.PlayerOptions(targetConnectionID))
+		uc.sendRejoinToken(roomID, targetConnectionID)
 
 	} else if action == "REJECT" {
 		if err := room.RejectPlayer(targetConnectionID); err != nil {
 			return err
 		}
+		uc.persist(room)
 		// Avisa o aluno e desconecta (opcional)
-		uc.hub.SendToPlayer(targetConnectionID, map[string]interface{}{
-			"type":    "error",
-			"payload": "Entrada negada pelo professor",
-		})
+		uc.sendJoinRejected(targetConnectionID, game.ErrEntradaRecusada)
 	} else {
 		return errors.New("ação inválida (use ACCEPT ou REJECT)")
 	}
@@ -149,6 +254,108 @@ func (uc *GameUseCases) ModerateEntry(roomID, teacherID, targetConnectionID, act
 	return nil
 }
 
+// ResolveRejoinToken valida o token de reconexão e retorna o jogador vinculado à sala.
+func (uc *GameUseCases) ResolveRejoinToken(roomID, token string) (string, error) {
+	tokenRoomID, playerID, err := uc.rejoinTokens.ValidateRejoinToken(token)
+	if err != nil {
+		return "", err
+	}
+	if tokenRoomID != roomID {
+		return "", errors.New("token de reconexão pertence a outra sala")
+	}
+	return playerID, nil
+}
+
+// ReconnectPlayer devolve ao aluno o seu jogador (pontuação e resposta atual) após queda de conexão.
+func (uc *GameUseCases) ReconnectPlayer(roomID, playerID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return errors.New("sala não encontrada")
+	}
+
+	rec, err := room.Reconnect(playerID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"player":  rec.Player,
+		"pending": rec.Pending,
+	}
+	if rec.Answer != nil {
+		payload["answerIndexes"] = room.DisplayIndexes(playerID, rec.Answer.Response.Indexes)
+		if rec.Answer.Response.Text != "" {
+			payload["answerText"] = rec.Answer.Response.Text
+		}
+	}
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type":    "rejoined",
+		"payload": payload,
+	})
+
+	if rec.Pending {
+		uc.hub.SendToPlayer(playerID, map[string]interface{}{
+			"type":    "entry_pending",
+			"payload": "Aguardando aprovação do professor",
+		})
+	} else {
+		uc.hub.SendToPlayer(playerID, map[string]interface{}{
+			"type":    "room_state",
+			"payload": room.GetPublicStateSnapshot(),
+		})
+		uc.sendPlayerOptions(playerID, room.PlayerOptions(playerID))
+	}
+
+	// Notifica o professor
+	uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, map[string]interface{}{
+		"type": "player_reconnected",
+		"payload": map[string]interface{}{
+			"connectionId": rec.Player.ID,
+			"nickname":     rec.Player.Nickname,
+			"pending":      rec.Pending,
+		},
+	})
+
+	return nil
+}
+
+// DisconnectPlayer marca o aluno como desconectado (mantém pontuação para reconexão).
+func (uc *GameUseCases) DisconnectPlayer(roomID, playerID string) {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return
+	}
+
+	player, ok := room.Disconnect(playerID)
+	if !ok {
+		return
+	}
+
+	// Só o professor acompanha a presença (inclui candidatos pendentes)
+	uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, map[string]interface{}{
+		"type": "player_disconnected",
+		"payload": map[string]interface{}{
+			"connectionId": player.ID,
+			"nickname":     player.Nickname,
+		},
+	})
+}
+
+// sendRejoinToken envia ao aluno o token que permite retomar seu lugar na sala.
+func (uc *GameUseCases) sendRejoinToken(roomID, playerID string) {
+	token, err := uc.rejoinTokens.GenerateRejoinToken(roomID, playerID)
+	if err != nil {
+		return
+	}
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type": "rejoin_token",
+		"payload": map[string]string{
+			"token":    token,
+			"playerId": playerID,
+		},
+	})
+}
+
 // KickPlayer remove um jogador aprovado da sala.
 func (uc *GameUseCases) KickPlayer(roomID, teacherID, targetConnectionID string) error {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
@@ -162,6 +369,7 @@ func (uc *GameUseCases) KickPlayer(roomID, teacherID, targetConnectionID string)
 	if err := room.RemovePlayer(targetConnectionID); err != nil {
 		return err
 	}
+	uc.persist(room)
 
 	// 1. Notifica o jogador expulso
 	uc.hub.SendToPlayer(targetConnectionID, map[string]interface{}{
@@ -170,14 +378,187 @@ func (uc *GameUseCases) KickPlayer(roomID, teacherID, targetConnectionID string)
 	})
 
 	// 2. Notifica a sala (opcional: player_left ou leaderboard_update)
-	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
-		"type":    "leaderboard_update",
-		"payload": room.GetLeaderboard(),
+	uc.broadcastLeaderboard(room)
+
+	return nil
+}
+
+// DefineTeams substitui os times da sala (apenas no lobby).
+func (uc *GameUseCases) DefineTeams(roomID, teacherID string, names []string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	teams, err := room.DefineTeams(names)
+	if err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	board := room.GetLeaderboard()
+	uc.broadcastPlayers(roomID, "", "teams_updated",
+		map[string]interface{}{"teams": teams, "players": board},
+		map[string]interface{}{"teams": teams, "players": game.PublicPlayers(board)},
+	)
+	uc.broadcastLeaderboard(room)
+	return nil
+}
+
+// AssignTeam move um aluno para outro time.
+func (uc *GameUseCases) AssignTeam(roomID, teacherID, targetConnectionID, teamID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	player, err := room.AssignTeam(targetConnectionID, teamID)
+	if err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	uc.broadcastPlayers(roomID, "", "team_assigned", player, player.Public())
+	uc.broadcastLeaderboard(room)
+	return nil
+}
+
+// sendPlayerOptions envia ao aluno as alternativas da pergunta atual na ordem em que ele deve
+// vê-las. As respostas (answerIndex/answerIndexes) usam as posições dessa lista.
+func (uc *GameUseCases) sendPlayerOptions(playerID string, options []string) {
+	if options == nil {
+		return
+	}
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type":    "question_options",
+		"payload": map[string]interface{}{"options": options},
+	})
+}
+
+// sendJoinRejected avisa o aluno que a entrada foi recusada, com o código do motivo.
+func (uc *GameUseCases) sendJoinRejected(playerID string, reason *game.Error) {
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type":    "join_rejected",
+		"payload": reason,
+	})
+}
+
+// RejectAnswer avisa o aluno que a resposta foi recusada, com o código do motivo.
+func (uc *GameUseCases) RejectAnswer(playerID string, reason *game.Error) {
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type":    "answer_rejected",
+		"payload": reason,
+	})
+}
+
+// broadcastPlayers envia um evento com dados de alunos. Só o professor recebe a versão com os
+// IDs de conexão (usados para moderar e expulsar); alunos e telas de projeção recebem a versão
+// pública. exceptPlayerID, se informado, é o aluno que não recebe o evento.
+func (uc *GameUseCases) broadcastPlayers(roomID, exceptPlayerID, eventType string, teacherPayload, publicPayload interface{}) {
+	uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, map[string]interface{}{
+		"type":    eventType,
+		"payload": teacherPayload,
+	})
+	public := map[string]interface{}{
+		"type":    eventType,
+		"payload": publicPayload,
+	}
+	uc.hub.BroadcastToRoleExcept(roomID, ports.RolePlayer, exceptPlayerID, public)
+	uc.hub.BroadcastToRole(roomID, ports.RoleSpectator, public)
+}
+
+// broadcastLeaderboard envia o placar dos alunos e, no modo times, o placar dos times.
+// As telas de projeção recebem também os primeiros colocados (display_leaderboard).
+func (uc *GameUseCases) broadcastLeaderboard(room *game.Room) {
+	board := room.GetLeaderboard()
+	uc.broadcastPlayers(room.ID, "", "leaderboard_update", board, game.PublicPlayers(board))
+	if room.Settings.TeamMode {
+		uc.hub.BroadcastToRoom(room.ID, map[string]interface{}{
+			"type":    "team_leaderboard_update",
+			"payload": room.GetTeamLeaderboard(),
+		})
+	}
+	uc.hub.BroadcastToRole(room.ID, ports.RoleSpectator, map[string]interface{}{
+		"type":    "display_leaderboard",
+		"payload": displayLeaderboard(room),
 	})
+}
+
+// IssueDisplayCode gera o código que autoriza uma tela de projeção (/ws?display=) na sala.
+func (uc *GameUseCases) IssueDisplayCode(roomID, teacherID string) (string, error) {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return "", ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return "", ErrNaoAutorizado
+	}
+
+	code, err := room.IssueDisplayCode()
+	if err != nil {
+		return "", err
+	}
+	uc.persist(room)
+	return code, nil
+}
+
+// AuthorizeDisplay valida o código de tela apresentado na conexão do projetor.
+func (uc *GameUseCases) AuthorizeDisplay(roomID, code string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	return room.CheckDisplayCode(code)
+}
 
+// ConnectDisplay envia à tela de projeção recém-conectada o estado atual da sala. A tela nunca
+// entra em Players nem em PendingPlayers: acompanha o jogo pelos eventos públicos da sala.
+func (uc *GameUseCases) ConnectDisplay(roomID, connectionID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+
+	state := room.GetStateSnapshot()
+	payload := map[string]interface{}{
+		"state":       state,
+		"leaderboard": displayLeaderboard(room),
+	}
+	if dist := revealedDistribution(room, state.Status); dist != nil {
+		payload["distribution"] = dist
+	}
+	uc.hub.SendToPlayer(connectionID, map[string]interface{}{
+		"type":    "display_state",
+		"payload": payload,
+	})
 	return nil
 }
 
+// displayLeaderboard monta o placar resumido da tela de projeção.
+func displayLeaderboard(room *game.Room) map[string]interface{} {
+	board := map[string]interface{}{"players": game.PublicPlayers(room.GetPodium(tamanhoPlacarTela))}
+	if room.Settings.TeamMode {
+		board["teams"] = room.GetTeamLeaderboard()
+	}
+	return board
+}
+
+// revealedDistribution retorna as respostas por alternativa da pergunta revelada (gráfico da tela).
+// Nil enquanto a pergunta está aberta ou quando ela não tem alternativas.
+func revealedDistribution(room *game.Room, status string) []int {
+	q := room.CurrentQuestion()
+	if q == nil || !q.HasOptions() || status != game.StateRevealed {
+		return nil
+	}
+	return room.Distribution()
+}
+
 // OpenQuestion abre a próxima pergunta ou a atual.
 func (uc *GameUseCases) OpenQuestion(roomID, teacherID string) error {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
@@ -191,45 +572,261 @@ func (uc *GameUseCases) OpenQuestion(roomID, teacherID string) error {
 	if err := room.NextQuestion(); err != nil {
 		return err
 	}
+	uc.persist(room)
 
-	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+	return uc.announceQuestion(room)
+}
+
+// announceQuestion envia a pergunta recém-aberta para a sala. Se o quiz acabou,
+// finaliza o jogo e retorna o erro do arquivamento (para ser exibido ao professor).
+func (uc *GameUseCases) announceQuestion(room *game.Room) error {
+	state := room.GetStateSnapshot()
+	uc.hub.BroadcastToRoom(room.ID, map[string]interface{}{
 		"type":    "question_opened",
+		"payload": state,
+	})
+	// Alternativas embaralhadas: cada aluno recebe a própria ordem
+	for playerID, options := range room.AllPlayerOptions() {
+		uc.sendPlayerOptions(playerID, options)
+	}
+	if state.Status == game.StateOpen {
+		uc.broadcastAnswerStats(room) // Todos pendentes
+	}
+
+	if state.Status == game.StateFinished {
+		return uc.finishGame(room)
+	}
+	return nil
+}
+
+// finishGame envia o pódio final e arquiva a sala.
+func (uc *GameUseCases) finishGame(room *game.Room) error {
+	board := room.GetLeaderboard()
+	podium := board
+	if len(podium) > tamanhoPodio {
+		podium = podium[:tamanhoPodio]
+	}
+	payload := map[string]interface{}{
+		"podium":      podium,
+		"leaderboard": board,
+	}
+	public := map[string]interface{}{
+		"podium":      game.PublicPlayers(podium),
+		"leaderboard": game.PublicPlayers(board),
+	}
+	if room.Settings.TeamMode {
+		teams := room.GetTeamLeaderboard()
+		payload["teams"] = teams
+		public["teams"] = teams
+	}
+	uc.broadcastPlayers(room.ID, "", "game_finished", payload, public)
+
+	if err := uc.historyUC.ArchiveRoom(context.Background(), room); err != nil {
+		logger.Error("Erro ao arquivar sala finalizada", "roomId", room.ID, "error", err)
+		return fmt.Errorf("%w: %v", ErrArquivamento, err)
+	}
+	return nil
+}
+
+// StartGame trava o lobby e inicia o jogo. Com autoRejectPending, os alunos que ainda
+// aguardavam aprovação são recusados.
+func (uc *GameUseCases) StartGame(roomID, teacherID string, autoRejectPending bool) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	rejected, err := room.StartGame(autoRejectPending)
+	if err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	for _, p := range rejected {
+		uc.sendJoinRejected(p.ID, game.ErrEntradaRecusada)
+	}
+	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+		"type":    "game_started",
 		"payload": room.GetStateSnapshot(),
 	})
+	return nil
+}
+
+// EndGame finaliza o jogo (inclusive antes da última pergunta), envia o pódio e arquiva a sala.
+func (uc *GameUseCases) EndGame(roomID, teacherID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	if err := room.EndGame(); err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	return uc.finishGame(room)
+}
 
-	// Verifica se acabou de finalizar o jogo
-	if room.Status == game.StateFinished {
-		// Arquiva automaticamente
-		go func() {
-			ctx := context.Background()
-			if err := uc.historyUC.ArchiveRoom(ctx, room); err != nil {
-				// Log silenciado por enquanto
-			}
-		}()
+// PauseRoom congela a pergunta aberta (cronômetro parado, respostas recusadas).
+func (uc *GameUseCases) PauseRoom(roomID, teacherID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
 	}
 
+	if err := room.Pause(); err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+		"type":    "room_paused",
+		"payload": room.GetStateSnapshot(),
+	})
 	return nil
 }
 
-// SubmitAnswer recebe a resposta do aluno.
-func (uc *GameUseCases) SubmitAnswer(roomID, playerID string, answerIndex int) error {
+// ResumeRoom retoma a pergunta pausada com o tempo que restava.
+func (uc *GameUseCases) ResumeRoom(roomID, teacherID string) error {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
 	if err != nil || room == nil {
-		return errors.New("sala não encontrada")
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
 	}
 
-	if err := room.SubmitAnswer(playerID, answerIndex); err != nil {
+	if err := room.Resume(); err != nil {
 		return err
 	}
+	uc.persist(room)
 
 	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
-		"type":    "answer_submitted",
-		"payload": map[string]int{"answersCount": len(room.Answers)},
+		"type":    "room_resumed",
+		"payload": room.GetStateSnapshot(),
+	})
+	return nil
+}
+
+// SkipQuestion pula a pergunta atual sem pontuar e abre a próxima.
+func (uc *GameUseCases) SkipQuestion(roomID, teacherID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	skipped, err := room.SkipQuestion()
+	if err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+		"type":    "question_skipped",
+		"payload": map[string]interface{}{"questionIndex": skipped},
+	})
+	return uc.announceQuestion(room)
+}
+
+// PreviousQuestion reabre a pergunta anterior, descartando as respostas dela em diante.
+func (uc *GameUseCases) PreviousQuestion(roomID, teacherID string) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	if err := room.PreviousQuestion(); err != nil {
+		return err
+	}
+	uc.persist(room)
+
+	// A pontuação foi recalculada sem as rodadas descartadas
+	uc.broadcastLeaderboard(room)
+	return uc.announceQuestion(room)
+}
+
+// SubmitAnswer recebe a resposta do aluno. As recusas com código já são enviadas ao aluno:
+// answer_locked para a troca recusada (FIRST_FINAL) e answer_rejected para as demais.
+func (uc *GameUseCases) SubmitAnswer(roomID, playerID string, resp quiz.Response) error {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return errors.New("sala não encontrada")
+	}
+
+	sub, err := room.SubmitAnswer(playerID, resp)
+	if err != nil {
+		var reason *game.Error
+		switch {
+		case errors.Is(err, game.ErrRespostaBloqueada):
+			uc.hub.SendToPlayer(playerID, map[string]interface{}{
+				"type":    "answer_locked",
+				"payload": game.ErrRespostaBloqueada,
+			})
+		case errors.As(err, &reason):
+			uc.RejectAnswer(playerID, reason)
+		}
+		return err
+	}
+	uc.persist(room)
+
+	// Confirmação para o aluno: final indica que a resposta não pode mais ser trocada
+	uc.hub.SendToPlayer(playerID, map[string]interface{}{
+		"type": "answer_accepted",
+		"payload": map[string]interface{}{
+			"questionIndex": sub.QuestionIndex,
+			"changes":       sub.Answer.Changes,
+			"final":         room.Settings.AnswerPolicy == game.RespostaDefinitiva,
+		},
 	})
 
+	countOnly := map[string]interface{}{
+		"type":    "answer_submitted",
+		"payload": map[string]interface{}{"answersCount": sub.AnswersCount},
+	}
+	if sub.Distribution == nil {
+		uc.hub.BroadcastToRoom(roomID, countOnly)
+	} else {
+		// Enquete: professor e tela acompanham a distribuição dos votos ao vivo; os alunos só a contagem
+		withVotes := map[string]interface{}{
+			"type": "answer_submitted",
+			"payload": map[string]interface{}{
+				"answersCount": sub.AnswersCount,
+				"distribution": sub.Distribution,
+			},
+		}
+		uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, withVotes)
+		uc.hub.BroadcastToRole(roomID, ports.RoleSpectator, withVotes)
+		uc.hub.BroadcastToRole(roomID, ports.RolePlayer, countOnly)
+	}
+	uc.broadcastAnswerStats(room)
+
 	return nil
 }
 
+// broadcastAnswerStats envia ao professor a distribuição das respostas e quem ainda falta responder.
+// Os alunos recebem apenas a contagem (answer_submitted), para que nenhuma resposta vaze.
+func (uc *GameUseCases) broadcastAnswerStats(room *game.Room) {
+	uc.hub.BroadcastToRole(room.ID, ports.RoleTeacher, map[string]interface{}{
+		"type":    "answer_stats",
+		"payload": room.GetAnswerStats(),
+	})
+}
+
 // RevealQuestion revela o resultado da pergunta atual.
 func (uc *GameUseCases) RevealQuestion(roomID, teacherID string) error {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
@@ -240,30 +837,89 @@ func (uc *GameUseCases) RevealQuestion(roomID, teacherID string) error {
 		return ErrNaoAutorizado
 	}
 
+	return uc.reveal(room)
+}
+
+// revealExpired revela automaticamente a pergunta cujo tempo acabou.
+func (uc *GameUseCases) revealExpired(roomID string) {
+	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil || room == nil {
+		return
+	}
+	// Erro aqui significa que o professor revelou antes do cronômetro
+	_ = uc.reveal(room)
+}
+
+// reveal calcula a pontuação e notifica a sala (fluxo comum a professor e cronômetro).
+func (uc *GameUseCases) reveal(room *game.Room) error {
+	roomID := room.ID
+
 	if err := room.RevealQuestion(); err != nil {
 		return err
 	}
+	uc.persist(room)
 
-	// Envia resultado e placar
-	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
+	// Envia resultado e placar. Só o professor recebe o gabarito com os índices originais; alunos
+	// e telas recebem o estado público e cada aluno vê as corretas no your_result, na própria ordem
+	state := room.GetStateSnapshot()
+	uc.hub.BroadcastToRole(roomID, ports.RoleTeacher, map[string]interface{}{
 		"type":    "question_revealed",
-		"payload": room.GetStateSnapshot(),
+		"payload": state,
+	})
+	public := map[string]interface{}{
+		"type":    "question_revealed",
+		"payload": room.GetPublicStateSnapshot(),
+	}
+	uc.hub.BroadcastToRole(roomID, ports.RolePlayer, public)
+	uc.hub.BroadcastToRole(roomID, ports.RoleSpectator, public)
+	// Tela de projeção: gabarito com o gráfico da distribuição das respostas
+	uc.hub.BroadcastToRole(roomID, ports.RoleSpectator, map[string]interface{}{
+		"type": "display_reveal",
+		"payload": map[string]interface{}{
+			"state":        state,
+			"distribution": revealedDistribution(room, state.Status),
+		},
 	})
 
 	// Leaderboard update
-	uc.hub.BroadcastToRoom(roomID, map[string]interface{}{
-		"type":    "leaderboard_update",
-		"payload": room.GetLeaderboard(),
-	})
+	uc.broadcastLeaderboard(room)
+
+	// Resultado individual: cada aluno recebe só o próprio desempenho
+	for _, res := range room.GetRoundResults() {
+		uc.hub.SendToPlayer(res.PlayerID, map[string]interface{}{
+			"type":    "your_result",
+			"payload": res,
+		})
+	}
 
 	return nil
 }
 
-// GetRoom retorna info da sala (para HTTP).
-func (uc *GameUseCases) GetRoom(ctx context.Context, roomID string) (*game.Room, error) {
+// CloseRoom encerra a sala a pedido do professor (arquiva se o quiz não terminou).
+func (uc *GameUseCases) CloseRoom(ctx context.Context, roomID, teacherID string) error {
 	room, err := uc.gameRepo.FindRoomByID(roomID)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return ErrSalaNaoEncontrada
+	}
+	if room.TeacherID != teacherID {
+		return ErrNaoAutorizado
+	}
+
+	return uc.lifecycle.CloseRoom(ctx, room, MotivoFechadaPeloProfessor)
+}
+
+// GetRoom retorna os dados públicos da sala (para HTTP). Aceita o ID ou o código de entrada.
+func (uc *GameUseCases) GetRoom(ctx context.Context, roomID string) (*game.PublicRoom, error) {
+	room, err := uc.findRoom(roomID)
 	if err != nil {
 		return nil, err
 	}
-	return room, nil
+	if room == nil {
+		return nil, nil
+	}
+	info := room.GetPublicInfo()
+	return &info, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"rankit/internal/domain/game"
 	"rankit/internal/domain/history"
+	"rankit/internal/domain/quiz"
 	"rankit/internal/ports"
 	"time"
 
@@ -22,8 +23,16 @@ func NewHistoryUseCases(historyRepo ports.HistoryRepository, gameRepo ports.Game
 	}
 }
 
-// ArchiveRoom converte uma sala de jogo em histórico persistente.
+// ArchiveRoom converte uma sala de jogo em histórico persistente. Cada sala é arquivada uma
+// única vez: se já foi arquivada (ou outro chamador está arquivando), não faz nada.
 func (uc *HistoryUseCases) ArchiveRoom(ctx context.Context, room *game.Room) error {
+	if !room.TryClaimArchive() {
+		return nil
+	}
+
+	res := room.GetResults()
+	now := time.Now()
+
 	// Mapeia Game -> History
 	h := &history.RoomHistory{
 		ID:                uuid.NewString(),
@@ -31,31 +40,113 @@ func (uc *HistoryUseCases) ArchiveRoom(ctx context.Context, room *game.Room) err
 		TeacherID:         room.TeacherID,
 		QuizID:            room.Quiz.ID,
 		QuizTitleSnapshot: room.Quiz.Title,
-		Status:            room.Status,
+		Status:            res.Status,
 		TotalQuestions:    len(room.Quiz.Questions),
-		StartedAt:         time.Now(), // Aproximação
-		FinishedAt:        time.Now(),
-		CreatedAt:         time.Now(),
+		StartedAt:         res.StartedAt,
+		FinishedAt:        res.FinishedAt,
+		CreatedAt:         now,
+		TeamScoring:       room.Settings.TeamScoring,
+	}
+	if h.FinishedAt.IsZero() {
+		h.FinishedAt = now
 	}
 
-	players := room.GetLeaderboard()
-	for _, p := range players {
-		correct := p.Score / 10
+	for _, t := range res.Teams {
+		h.Teams = append(h.Teams, history.TeamStats{
+			ID:            uuid.NewString(),
+			RoomHistoryID: h.ID,
+			TeamRuntimeID: t.ID,
+			Name:          t.Name,
+			Score:         t.Score,
+			MemberCount:   t.MemberCount,
+		})
+	}
+
+	// Jogadores: ID de runtime -> ID do registro histórico (FK de room_answers)
+	historyPlayerIDs := make(map[string]string, len(res.Players))
+	for _, p := range res.Players {
 		hP := history.PlayerStats{
 			ID:              uuid.NewString(),
 			RoomHistoryID:   h.ID,
 			PlayerRuntimeID: p.ID,
 			Nickname:        p.Nickname,
 			Score:           p.Score,
-			CorrectCount:    correct,
-			WrongCount:      0,
+			TeamID:          p.TeamID,
 		}
+		historyPlayerIDs[p.ID] = hP.ID
 		h.Players = append(h.Players, hP)
 	}
 
-	// Não salvamos perguntas individuais por enquanto pois room in-memory não tem histórico.
+	for _, rd := range res.Rounds {
+		q := room.Quiz.Questions[rd.QuestionIndex]
+		qs := history.QuestionStats{
+			ID:             uuid.NewString(),
+			RoomHistoryID:  h.ID,
+			QuestionIndex:  rd.QuestionIndex,
+			QuestionID:     q.ID,
+			PromptSnapshot: q.Prompt,
+			QuestionType:   q.Type,
+			CorrectIndexes: q.CorrectIndexes,
+			OptionCounts:   make([]int, len(q.Options)),
+			Skipped:        rd.Skipped,
+		}
+
+		for i := range h.Players {
+			hP := &h.Players[i]
+			selected := []int{} // Não respondeu
+			var resp quiz.Response
+			correct := false
+			changes := 0
+			if ans, ok := rd.Answers[hP.PlayerRuntimeID]; ok {
+				if ans.Response.Indexes != nil {
+					selected = ans.Response.Indexes
+				}
+				resp = ans.Response
+				correct = ans.Correct
+				changes = ans.Changes
+				for _, idx := range selected {
+					if idx >= 0 && idx < len(qs.OptionCounts) {
+						qs.OptionCounts[idx]++
+					}
+				}
+			}
+
+			// Enquetes e perguntas puladas entram só na contagem de votos, não em acertos/erros
+			if q.IsScored() && !rd.Skipped {
+				if correct {
+					qs.CorrectCount++
+					hP.CorrectCount++
+				} else if rd.Revealed() {
+					// Errou ou deixou sem resposta uma pergunta pontuada
+					hP.WrongCount++
+				}
+			}
+
+			h.Answers = append(h.Answers, history.PlayerAnswer{
+				ID:              uuid.NewString(),
+				RoomHistoryID:   h.ID,
+				QuestionIndex:   rd.QuestionIndex,
+				RoomPlayerID:    historyPlayerIDs[hP.PlayerRuntimeID],
+				SelectedIndexes: selected,
+				AnswerText:      resp.Text,
+				Order:           resp.Order,
+				Pairs:           resp.Pairs,
+				IsCorrect:       correct,
+				ChangeCount:     changes,
+			})
+		}
+
+		h.Questions = append(h.Questions, qs)
+	}
+
+	if err := uc.historyRepo.SaveHistory(ctx, h); err != nil {
+		room.ReleaseArchive()
+		return err
+	}
+	room.MarkArchived()
 
-	return uc.historyRepo.SaveHistory(ctx, h)
+	// Registra o arquivamento no estado salvo, para não arquivar de novo após reinício
+	return uc.gameRepo.SaveRoom(room)
 }
 
 // ------ REPORT METHODS ------
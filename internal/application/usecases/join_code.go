@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"errors"
+	"rankit/internal/domain/joincode"
+)
+
+var ErrCodigoIndisponivel = errors.New("não foi possível gerar um código livre, tente novamente")
+
+// tentativasCodigo limita o sorteio de códigos de entrada quando há colisão com códigos em uso.
+const tentativasCodigo = 20
+
+// newJoinCode sorteia um código de entrada (sala ao vivo ou tarefa) que `inUse` informe estar livre.
+func newJoinCode(inUse func(code string) (bool, error)) (string, error) {
+	for i := 0; i < tentativasCodigo; i++ {
+		code, err := joincode.Generate()
+		if err != nil {
+			return "", err
+		}
+		taken, err := inUse(code)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+	return "", ErrCodigoIndisponivel
+}
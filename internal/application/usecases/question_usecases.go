@@ -37,15 +37,64 @@ func (uc *QuestionUseCases) ensureDraftAndOwner(ctx context.Context, quizID, tea
 	return q, nil
 }
 
+// QuestionFields são os campos editáveis de uma pergunta, comuns a criação e edição.
+type QuestionFields struct {
+	Type           string   `json:"type"` // MULTIPLE_CHOICE (padrão), TRUE_FALSE, MULTI_SELECT, OPEN_TEXT, NUMERIC, POLL, ORDERING ou MATCHING
+	Prompt         string   `json:"prompt"`
+	Options        []string `json:"options"`
+	CorrectIndexes []int    `json:"correctIndexes"`
+	TimeLimit      int      `json:"timeLimitSeconds"` // 0 = sem limite
+
+	PointsMultiplier int `json:"pointsMultiplier"` // 1 (padrão) a 3; 2 = pontos em dobro
+
+	AcceptedAnswers []string `json:"acceptedAnswers"` // OPEN_TEXT
+	NumericAnswer   *float64 `json:"numericAnswer"`   // NUMERIC
+	Tolerance       float64  `json:"tolerance"`       // NUMERIC
+
+	Items      []string `json:"items"`      // ORDERING, na ordem correta
+	MatchLeft  []string `json:"matchLeft"`  // MATCHING
+	MatchRight []string `json:"matchRight"` // MATCHING, matchRight[i] corresponde a matchLeft[i]
+
+	// Formato legado (4 alternativas fixas), usado quando "options" não é enviado
+	OptionA      string `json:"optionA,omitempty"`
+	OptionB      string `json:"optionB,omitempty"`
+	OptionC      string `json:"optionC,omitempty"`
+	OptionD      string `json:"optionD,omitempty"`
+	CorrectIndex *int   `json:"correctIndex,omitempty"`
+}
+
+// toData converte a entrada (inclusive o formato legado) nos dados da pergunta.
+func (f QuestionFields) toData() quiz.QuestionData {
+	data := quiz.QuestionData{
+		Type:           f.Type,
+		Prompt:         f.Prompt,
+		Options:        f.Options,
+		CorrectIndexes: f.CorrectIndexes,
+		TimeLimit:      f.TimeLimit,
+
+		PointsMultiplier: f.PointsMultiplier,
+
+		AcceptedAnswers: f.AcceptedAnswers,
+		NumericAnswer:   f.NumericAnswer,
+		Tolerance:       f.Tolerance,
+
+		Items:      f.Items,
+		MatchLeft:  f.MatchLeft,
+		MatchRight: f.MatchRight,
+	}
+	if len(data.Options) == 0 && (f.OptionA != "" || f.OptionB != "" || f.OptionC != "" || f.OptionD != "") {
+		data.Options = []string{f.OptionA, f.OptionB, f.OptionC, f.OptionD}
+	}
+	if len(data.CorrectIndexes) == 0 && f.CorrectIndex != nil {
+		data.CorrectIndexes = []int{*f.CorrectIndex}
+	}
+	return data
+}
+
 type AddQuestionInput struct {
-	QuizID       string `json:"-"` // Path param
-	TeacherID    string `json:"-"` // Context
-	Prompt       string `json:"prompt"`
-	OptionA      string `json:"optionA"`
-	OptionB      string `json:"optionB"`
-	OptionC      string `json:"optionC"`
-	OptionD      string `json:"optionD"`
-	CorrectIndex int    `json:"correctIndex"`
+	QuizID    string `json:"-"` // Path param
+	TeacherID string `json:"-"` // Context
+	QuestionFields
 }
 
 func (uc *QuestionUseCases) AddQuestion(ctx context.Context, input AddQuestionInput) (*quiz.Question, error) {
@@ -60,11 +109,7 @@ func (uc *QuestionUseCases) AddQuestion(ctx context.Context, input AddQuestionIn
 	// O repo atual carrega questions no FindByID.
 	nextOrder := len(q.Questions) + 1
 
-	newQ, err := quiz.NewQuestion(
-		input.QuizID, input.Prompt,
-		input.OptionA, input.OptionB, input.OptionC, input.OptionD,
-		input.CorrectIndex, nextOrder,
-	)
+	newQ, err := quiz.NewQuestion(input.QuizID, input.toData(), nextOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -77,15 +122,10 @@ func (uc *QuestionUseCases) AddQuestion(ctx context.Context, input AddQuestionIn
 }
 
 type UpdateQuestionInput struct {
-	QuizID       string `json:"-"`
-	QuestionID   string `json:"-"`
-	TeacherID    string `json:"-"`
-	Prompt       string `json:"prompt"`
-	OptionA      string `json:"optionA"`
-	OptionB      string `json:"optionB"`
-	OptionC      string `json:"optionC"`
-	OptionD      string `json:"optionD"`
-	CorrectIndex int    `json:"correctIndex"`
+	QuizID     string `json:"-"`
+	QuestionID string `json:"-"`
+	TeacherID  string `json:"-"`
+	QuestionFields
 }
 
 func (uc *QuestionUseCases) UpdateQuestion(ctx context.Context, input UpdateQuestionInput) (*quiz.Question, error) {
@@ -122,7 +162,7 @@ func (uc *QuestionUseCases) UpdateQuestion(ctx context.Context, input UpdateQues
 	}
 
 	// Atualiza
-	if err := targetQ.Update(input.Prompt, input.OptionA, input.OptionB, input.OptionC, input.OptionD, input.CorrectIndex); err != nil {
+	if err := targetQ.Update(input.toData()); err != nil {
 		return nil, err
 	}
 
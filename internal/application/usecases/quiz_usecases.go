@@ -28,10 +28,11 @@ type CreateQuizInput struct {
 	Description string
 	Subject     string
 	Grade       string
+	ScoringMode string // FLAT (padrão) | SPEED
 }
 
 func (uc *QuizUseCases) CreateQuiz(ctx context.Context, input CreateQuizInput) (*quiz.Quiz, error) {
-	q, err := quiz.NewQuiz(input.TeacherID, input.Title, input.Description, input.Subject, input.Grade)
+	q, err := quiz.NewQuiz(input.TeacherID, input.Title, input.Description, input.Subject, input.Grade, input.ScoringMode)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +72,7 @@ type UpdateQuizInput struct {
 	Description string
 	Subject     string
 	Grade       string
+	ScoringMode string
 }
 
 func (uc *QuizUseCases) UpdateQuiz(ctx context.Context, input UpdateQuizInput) (*quiz.Quiz, error) {
@@ -80,7 +82,7 @@ func (uc *QuizUseCases) UpdateQuiz(ctx context.Context, input UpdateQuizInput) (
 	}
 
 	// Domínio checa se pode editar (ex: se é DRAFT)
-	if err := q.UpdateMetadata(input.Title, input.Description, input.Subject, input.Grade); err != nil {
+	if err := q.UpdateMetadata(input.Title, input.Description, input.Subject, input.Grade, input.ScoringMode); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"rankit/internal/domain/game"
+	"rankit/internal/infra/logger"
+	"rankit/internal/ports"
+	"time"
+)
+
+// Motivos de encerramento enviados no evento room_closed.
+const (
+	MotivoFechadaPeloProfessor = "closed_by_teacher"
+	MotivoExpirada             = "expired"
+)
+
+// RoomLifecycleManager encerra salas: arquiva, desconecta os clientes e remove do repositório.
+type RoomLifecycleManager struct {
+	gameRepo      ports.GameRepository
+	hub           ports.RealTimeHub
+	historyUC     *HistoryUseCases
+	idleTTL       time.Duration
+	maxTTL        time.Duration
+	sweepInterval time.Duration
+}
+
+func NewRoomLifecycleManager(
+	gameRepo ports.GameRepository,
+	hub ports.RealTimeHub,
+	historyUC *HistoryUseCases,
+	idleTTL, maxTTL, sweepInterval time.Duration,
+) *RoomLifecycleManager {
+	return &RoomLifecycleManager{
+		gameRepo:      gameRepo,
+		hub:           hub,
+		historyUC:     historyUC,
+		idleTTL:       idleTTL,
+		maxTTL:        maxTTL,
+		sweepInterval: sweepInterval,
+	}
+}
+
+// Run executa a varredura periódica de salas expiradas até o contexto ser cancelado.
+func (m *RoomLifecycleManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.Sweep(ctx, now)
+		}
+	}
+}
+
+// Sweep encerra as salas que passaram do tempo ocioso ou do tempo máximo de vida.
+func (m *RoomLifecycleManager) Sweep(ctx context.Context, now time.Time) {
+	rooms, err := m.gameRepo.ListRooms()
+	if err != nil {
+		logger.Error("Falha ao listar salas para expiração", "erro", err)
+		return
+	}
+
+	for _, room := range rooms {
+		if !room.Expired(now, m.idleTTL, m.maxTTL) {
+			continue
+		}
+		if err := m.CloseRoom(ctx, room, MotivoExpirada); err != nil {
+			// A sala continua no repositório e será tentada na próxima varredura
+			logger.Error("Falha ao encerrar sala expirada", "sala", room.ID, "erro", err)
+			continue
+		}
+		logger.Info("Sala expirada encerrada", "sala", room.ID)
+	}
+}
+
+// CloseRoom arquiva a sala (como ABANDONED se o quiz não terminou), avisa e desconecta
+// os clientes e remove a sala do repositório.
+func (m *RoomLifecycleManager) CloseRoom(ctx context.Context, room *game.Room, reason string) error {
+	room.Abandon()
+
+	// Salas que nunca saíram do lobby não geram histórico (ArchiveRoom ignora as já arquivadas)
+	if room.Started() {
+		if err := m.historyUC.ArchiveRoom(ctx, room); err != nil {
+			return err
+		}
+	}
+
+	m.hub.BroadcastToRoom(room.ID, map[string]interface{}{
+		"type":    "room_closed",
+		"payload": map[string]string{"reason": reason},
+	})
+	m.hub.CloseRoom(room.ID)
+
+	room.Close()
+	return m.gameRepo.DeleteRoom(room.ID)
+}
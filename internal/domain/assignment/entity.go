@@ -0,0 +1,239 @@
+package assignment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"rankit/internal/domain/quiz"
+	"rankit/internal/domain/scoring"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Situação da tarefa, calculada a partir do período de entrega
+const (
+	StatusAgendada  = "SCHEDULED" // Ainda não abriu
+	StatusAberta    = "OPEN"      // Aceitando tentativas
+	StatusEncerrada = "CLOSED"    // Prazo terminou (ou foi encerrada pelo professor)
+)
+
+var (
+	ErrQuizNaoPublicado    = errors.New("apenas quizzes publicados podem ser passados como tarefa")
+	ErrPrazoObrigatorio    = errors.New("a data de encerramento (closesAt) é obrigatória")
+	ErrPeriodoInvalido     = errors.New("a data de encerramento deve ser posterior à de abertura")
+	ErrTarefaNaoIniciada   = errors.New("a tarefa ainda não está aberta")
+	ErrTarefaEncerrada     = errors.New("o prazo da tarefa já terminou")
+	ErrApelidoObrigatorio  = errors.New("o apelido é obrigatório")
+	ErrTentativaConcluida  = errors.New("a tentativa já foi concluída")
+	ErrPerguntaForaDeOrdem = errors.New("responda a pergunta atual da tarefa")
+)
+
+// Assignment é um quiz publicado passado como tarefa: os alunos respondem no próprio ritmo,
+// dentro do período de entrega, sem um professor conduzindo as perguntas.
+type Assignment struct {
+	ID        string    `json:"id"`
+	TeacherID string    `json:"teacherId"`
+	QuizID    string    `json:"quizId"`
+	Title     string    `json:"title"`
+	Code      string    `json:"code"` // Código compartilhado com os alunos
+	OpensAt   time.Time `json:"opensAt"`
+	ClosesAt  time.Time `json:"closesAt"`
+	HistoryID string    `json:"historyId,omitempty"` // Registro em rooms_history após o arquivamento
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewAssignment cria uma tarefa para o quiz. Sem `opensAt`, a tarefa abre imediatamente;
+// sem título, usa o título do quiz.
+func NewAssignment(teacherID string, q *quiz.Quiz, title, code string, opensAt, closesAt time.Time) (*Assignment, error) {
+	if q.Status != quiz.StatusPublicado {
+		return nil, ErrQuizNaoPublicado
+	}
+	now := time.Now()
+	if opensAt.IsZero() {
+		opensAt = now
+	}
+	if closesAt.IsZero() {
+		return nil, ErrPrazoObrigatorio
+	}
+	if !closesAt.After(opensAt) {
+		return nil, ErrPeriodoInvalido
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = q.Title
+	}
+
+	return &Assignment{
+		ID:        uuid.NewString(),
+		TeacherID: teacherID,
+		QuizID:    q.ID,
+		Title:     title,
+		Code:      code,
+		OpensAt:   opensAt,
+		ClosesAt:  closesAt,
+		CreatedAt: now,
+	}, nil
+}
+
+// Status retorna a situação da tarefa no instante `now`.
+func (a *Assignment) Status(now time.Time) string {
+	switch {
+	case now.Before(a.OpensAt):
+		return StatusAgendada
+	case now.Before(a.ClosesAt):
+		return StatusAberta
+	default:
+		return StatusEncerrada
+	}
+}
+
+// CheckOpen retorna erro se a tarefa não estiver aceitando respostas em `now`.
+func (a *Assignment) CheckOpen(now time.Time) error {
+	switch a.Status(now) {
+	case StatusAgendada:
+		return ErrTarefaNaoIniciada
+	case StatusEncerrada:
+		return ErrTarefaEncerrada
+	}
+	return nil
+}
+
+// Close antecipa o encerramento para `now` (não altera tarefas já encerradas).
+func (a *Assignment) Close(now time.Time) {
+	if now.Before(a.ClosesAt) {
+		a.ClosesAt = now
+	}
+	if now.Before(a.OpensAt) {
+		a.OpensAt = now
+	}
+}
+
+// Attempt é a tentativa de um aluno: as perguntas são respondidas uma a uma, na ordem do quiz.
+type Attempt struct {
+	ID           string          `json:"id"`
+	AssignmentID string          `json:"assignmentId"`
+	Nickname     string          `json:"nickname"`
+	Token        string          `json:"-"`            // Identifica o aluno nos endpoints públicos
+	CurrentIndex int             `json:"currentIndex"` // Próxima pergunta a responder
+	Score        int             `json:"score"`
+	Shuffle      []int           `json:"-"` // Ordem exibida dos itens da pergunta atual (ORDERING/MATCHING)
+	StartedAt    time.Time       `json:"startedAt"`
+	FinishedAt   time.Time       `json:"finishedAt"` // Zero enquanto a tentativa está em andamento
+	Answers      []AttemptAnswer `json:"answers,omitempty"`
+}
+
+// AttemptAnswer é a resposta do aluno a uma pergunta, gravada assim que enviada.
+type AttemptAnswer struct {
+	ID            string        `json:"id"`
+	AttemptID     string        `json:"attemptId"`
+	QuestionIndex int           `json:"questionIndex"`
+	Response      quiz.Response `json:"response"` // Índices originais da pergunta
+	Credit        float64       `json:"credit"`   // De 0 a 1 (crédito parcial)
+	Correct       bool          `json:"correct"`
+	Points        int           `json:"points"`
+	AnsweredAt    time.Time     `json:"answeredAt"`
+}
+
+// NewAttempt inicia a tentativa de um aluno na tarefa.
+func NewAttempt(a *Assignment, q *quiz.Quiz, nickname string, now time.Time) (*Attempt, error) {
+	if err := a.CheckOpen(now); err != nil {
+		return nil, err
+	}
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" {
+		return nil, ErrApelidoObrigatorio
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Attempt{
+		ID:           uuid.NewString(),
+		AssignmentID: a.ID,
+		Nickname:     nickname,
+		Token:        token,
+		StartedAt:    now,
+	}
+	t.prepare(q)
+	return t, nil
+}
+
+// Finished indica se o aluno já respondeu todas as perguntas.
+func (t *Attempt) Finished() bool {
+	return !t.FinishedAt.IsZero()
+}
+
+// CurrentQuestion retorna a pergunta atual sem o gabarito (false se a tentativa terminou).
+func (t *Attempt) CurrentQuestion(q *quiz.Quiz) (quiz.Question, bool) {
+	if t.Finished() || t.CurrentIndex >= len(q.Questions) {
+		return quiz.Question{}, false
+	}
+	return q.Questions[t.CurrentIndex].PublicView(t.Shuffle), true
+}
+
+// Answer corrige a resposta da pergunta `index` (que deve ser a atual) e avança a tentativa.
+// Sem cronômetro ao vivo, toda tarefa usa a pontuação fixa (FLAT), com o multiplicador da pergunta.
+func (t *Attempt) Answer(q *quiz.Quiz, index int, resp quiz.Response, now time.Time) (*AttemptAnswer, error) {
+	if t.Finished() {
+		return nil, ErrTentativaConcluida
+	}
+	if index != t.CurrentIndex || index >= len(q.Questions) {
+		return nil, ErrPerguntaForaDeOrdem
+	}
+
+	question := q.Questions[index]
+	if err := question.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	resp = question.ToCanonical(resp, t.Shuffle)
+
+	ans := &AttemptAnswer{
+		ID:            uuid.NewString(),
+		AttemptID:     t.ID,
+		QuestionIndex: index,
+		Response:      resp,
+		AnsweredAt:    now,
+	}
+	if question.IsScored() {
+		multiplier := question.PointsMultiplier
+		if multiplier < quiz.MultiplicadorMinimo {
+			multiplier = quiz.MultiplicadorMinimo
+		}
+		ans.Credit = question.Grade(resp)
+		ans.Correct = ans.Credit >= 1
+		ans.Points = int(math.Round(float64(scoring.PontosFixos) * ans.Credit * float64(multiplier)))
+	}
+
+	t.Answers = append(t.Answers, *ans)
+	t.Score += ans.Points
+	t.CurrentIndex++
+	if t.CurrentIndex >= len(q.Questions) {
+		t.FinishedAt = now
+	}
+	t.prepare(q)
+	return ans, nil
+}
+
+// prepare sorteia a ordem de exibição dos itens da pergunta atual, se ela precisar.
+func (t *Attempt) prepare(q *quiz.Quiz) {
+	t.Shuffle = nil
+	if t.Finished() || t.CurrentIndex >= len(q.Questions) {
+		return
+	}
+	if n := q.Questions[t.CurrentIndex].ShuffleSize(); n > 0 {
+		t.Shuffle = quiz.NewShuffle(n)
+	}
+}
+
+// newToken gera o token aleatório da tentativa.
+func newToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
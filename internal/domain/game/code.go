@@ -0,0 +1,25 @@
+package game
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// Código da tela de projeção: letras e dígitos sem os caracteres ambíguos (0/O, 1/I/L).
+const (
+	alfabetoCodigoTela = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	tamanhoCodigoTela  = 8
+)
+
+// GenerateDisplayCode sorteia o código que autoriza uma tela de projeção na sala.
+func GenerateDisplayCode() (string, error) {
+	code := make([]byte, tamanhoCodigoTela)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alfabetoCodigoTela))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = alfabetoCodigoTela[n.Int64()]
+	}
+	return string(code), nil
+}
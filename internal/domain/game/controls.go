@@ -0,0 +1,204 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrJogoNaoIniciado     = errors.New("o jogo ainda não foi iniciado (use teacher_start_game)")
+	ErrSemJogadores        = errors.New("aprove ao menos um aluno antes de iniciar o jogo")
+	ErrSalaPausada         = errors.New("a sala está pausada")
+	ErrSalaNaoPausada      = errors.New("a sala não está pausada")
+	ErrPausaNaoPermitida   = errors.New("só é possível pausar com uma pergunta aberta")
+	ErrSemPerguntaAtual    = errors.New("não há pergunta em andamento")
+	ErrSemPerguntaAnterior = errors.New("não há pergunta anterior para reabrir")
+)
+
+// StartGame trava o lobby e registra o início do jogo. Com autoRejectPending, os alunos
+// que ainda aguardavam aprovação são recusados (e retornados para serem avisados).
+func (r *Room) StartGame(autoRejectPending bool) ([]Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if r.Status != StateLobby {
+		return nil, ErrSalaIniciada
+	}
+	if len(r.Players) == 0 {
+		return nil, ErrSemJogadores
+	}
+
+	var rejected []Player
+	if autoRejectPending {
+		for id, p := range r.PendingPlayers {
+			rejected = append(rejected, *p)
+			delete(r.PendingPlayers, id)
+		}
+	}
+
+	r.Status = StateStarted
+	r.StartedAt = time.Now()
+	return rejected, nil
+}
+
+// EndGame finaliza o jogo antes da última pergunta. Uma pergunta aberta é encerrada sem pontuar.
+func (r *Room) EndGame() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	switch r.Status {
+	case StateLobby:
+		return ErrJogoNaoIniciado
+	case StateFinished, StateAbandoned:
+		return ErrJogoFinalizado
+	}
+
+	r.stopTimer()
+	r.Status = StateFinished
+	r.FinishedAt = time.Now()
+	r.PausedAt = time.Time{}
+	r.Deadline = time.Time{}
+	return nil
+}
+
+// GetPodium retorna os `n` primeiros colocados do placar.
+func (r *Room) GetPodium(n int) []Player {
+	board := r.GetLeaderboard()
+	if len(board) > n {
+		board = board[:n]
+	}
+	return board
+}
+
+// Pause congela a pergunta aberta, guardando o tempo restante.
+func (r *Room) Pause() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if r.Status == StatePaused {
+		return ErrSalaPausada
+	}
+	if r.Status != StateOpen {
+		return ErrPausaNaoPermitida
+	}
+
+	r.stopTimer()
+	r.Status = StatePaused
+	r.PausedAt = time.Now()
+	return nil
+}
+
+// Resume reabre a pergunta pausada. Abertura e prazo são adiantados pelo tempo de pausa,
+// assim o tempo restante e a pontuação por velocidade não contam o período pausado.
+func (r *Room) Resume() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if r.Status != StatePaused {
+		return ErrSalaNaoPausada
+	}
+
+	paused := time.Since(r.PausedAt)
+	r.OpenedAt = r.OpenedAt.Add(paused)
+	r.PausedAt = time.Time{}
+	r.Status = StateOpen
+
+	if !r.Deadline.IsZero() {
+		r.Deadline = r.Deadline.Add(paused)
+		r.armTimer(r.CurrentQuestionIndex, time.Until(r.Deadline))
+	}
+	return nil
+}
+
+// SkipQuestion encerra a pergunta atual sem pontuá-la e abre a próxima
+// (ou finaliza o jogo, se era a última). Retorna o índice da pergunta pulada.
+func (r *Room) SkipQuestion() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if r.Status != StateOpen && r.Status != StatePaused {
+		return 0, ErrSemPerguntaAtual
+	}
+
+	skipped := r.CurrentQuestionIndex
+	if round := r.currentRound(); round != nil {
+		round.Skipped = true
+	}
+	r.PausedAt = time.Time{}
+	r.advance()
+	return skipped, nil
+}
+
+// PreviousQuestion volta para a pergunta anterior e a reabre do zero. As rodadas a partir
+// dela são descartadas e a pontuação é recalculada com as rodadas que sobraram.
+func (r *Room) PreviousQuestion() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if r.Status == StateLobby || r.Status == StateStarted || r.Status == StateFinished || r.Status == StateAbandoned {
+		return ErrSemPerguntaAtual
+	}
+	target := r.CurrentQuestionIndex - 1
+	if target < 0 {
+		return ErrSemPerguntaAnterior
+	}
+
+	r.stopTimer()
+	kept := r.Rounds[:0]
+	for _, rd := range r.Rounds {
+		if rd.QuestionIndex < target {
+			kept = append(kept, rd)
+		}
+	}
+	r.Rounds = kept
+	delete(r.shuffles, target)
+	delete(r.optionOrders, target)
+	r.recomputeScores()
+
+	r.PausedAt = time.Time{}
+	r.CurrentQuestionIndex = target - 1
+	r.advance()
+	return nil
+}
+
+// recomputeScores refaz pontuação, sequência e posições a partir das rodadas reveladas. Cada
+// rodada pontua apenas quem participou dela, como na revelação ao vivo: quem entrou depois não
+// perde a sequência nem soma tempo de resposta por perguntas anteriores à sua entrada.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) recomputeScores() {
+	for _, p := range r.Players {
+		p.Score, p.Streak, p.LastPoints, p.AnswerTimeMs = p.StartingScore, 0, 0, 0
+	}
+	for _, rd := range r.Rounds {
+		q := r.Quiz.Questions[rd.QuestionIndex]
+		if rd.Revealed() && !rd.Skipped && q.IsScored() {
+			r.scoreAnswers(q, rd.Answers, r.participants(rd))
+		}
+	}
+	for _, p := range r.Players {
+		p.LastPoints = 0
+	}
+	r.recordPreviousRanks()
+	r.updateRanks()
+}
+
+// participants retorna os alunos da rodada que ainda estão na sala. Rodadas gravadas antes do
+// registro de participantes consideram todos os alunos. Deve ser chamado com o lock adquirido.
+func (r *Room) participants(rd *Round) []*Player {
+	if rd.Participants == nil {
+		return r.playerList()
+	}
+	players := make([]*Player, 0, len(rd.Participants))
+	for _, id := range rd.Participants {
+		if p, ok := r.Players[id]; ok {
+			players = append(players, p)
+		}
+	}
+	return players
+}
@@ -0,0 +1,271 @@
+package game
+
+import (
+	"errors"
+	"rankit/internal/domain/quiz"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// timedQuestion cria uma pergunta de múltipla escolha com tempo limite.
+func timedQuestion(correct, seconds int) quiz.Question {
+	q := choiceQuestion(correct)
+	q.TimeLimit = seconds
+	return q
+}
+
+// answerAndReveal envia as respostas da pergunta aberta e a revela.
+func answerAndReveal(t *testing.T, r *Room, answers map[string]int) {
+	t.Helper()
+	for playerID, idx := range answers {
+		if _, err := r.SubmitAnswer(playerID, quiz.Response{Indexes: []int{idx}}); err != nil {
+			t.Fatalf("SubmitAnswer(%s): %v", playerID, err)
+		}
+	}
+	if err := r.RevealQuestion(); err != nil {
+		t.Fatalf("RevealQuestion: %v", err)
+	}
+}
+
+func TestPauseResumeShiftsDeadline(t *testing.T) {
+	tests := []struct {
+		name   string
+		paused time.Duration
+	}{
+		{name: "pausa curta", paused: 2 * time.Second},
+		{name: "pausa maior que o tempo restante", paused: 45 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoVelocidade, timedQuestion(0, 30))
+			admitPlayers(t, r, "p1")
+			startGame(t, r)
+			defer r.Close()
+
+			openedAt, deadline := r.OpenedAt, r.Deadline
+			if err := r.Pause(); err != nil {
+				t.Fatalf("Pause: %v", err)
+			}
+			// Simula o tempo parado recuando o início da pausa
+			r.PausedAt = r.PausedAt.Add(-tt.paused)
+
+			remaining := r.GetStateSnapshot().RemainingMs
+			if want := deadline.Sub(r.PausedAt).Milliseconds(); remaining != want {
+				t.Fatalf("tempo restante congelado = %dms, esperado %dms", remaining, want)
+			}
+			if _, err := r.SubmitAnswer("p1", quiz.Response{Indexes: []int{0}}); !errors.Is(err, ErrRespostaSalaPausada) {
+				t.Fatalf("resposta durante a pausa: %v", err)
+			}
+
+			if err := r.Resume(); err != nil {
+				t.Fatalf("Resume: %v", err)
+			}
+			const slack = 200 * time.Millisecond // Tempo real gasto entre Pause e Resume
+			if shift := r.Deadline.Sub(deadline); shift < tt.paused || shift > tt.paused+slack {
+				t.Fatalf("prazo adiantado em %v, esperado %v", shift, tt.paused)
+			}
+			if shift := r.OpenedAt.Sub(openedAt); shift < tt.paused || shift > tt.paused+slack {
+				t.Fatalf("abertura adiantada em %v, esperado %v", shift, tt.paused)
+			}
+			if !r.PausedAt.IsZero() || r.Status != StateOpen {
+				t.Fatalf("estado após retomar = %s (pausedAt %v)", r.Status, r.PausedAt)
+			}
+
+			// O tempo pausado não conta na velocidade da resposta
+			sub, err := r.SubmitAnswer("p1", quiz.Response{Indexes: []int{0}})
+			if err != nil {
+				t.Fatalf("resposta após retomar: %v", err)
+			}
+			if sub.Answer.Elapsed >= slack {
+				t.Fatalf("tempo de resposta = %v, a pausa foi contada", sub.Answer.Elapsed)
+			}
+		})
+	}
+}
+
+func TestPauseRequiresOpenQuestion(t *testing.T) {
+	r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoFixa, choiceQuestion(0))
+	admitPlayers(t, r, "p1")
+
+	if err := r.Pause(); !errors.Is(err, ErrPausaNaoPermitida) {
+		t.Fatalf("pausa no lobby: %v", err)
+	}
+	startGame(t, r)
+	if err := r.Resume(); !errors.Is(err, ErrSalaNaoPausada) {
+		t.Fatalf("retomar sem pausa: %v", err)
+	}
+	if err := r.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if err := r.Pause(); !errors.Is(err, ErrSalaPausada) {
+		t.Fatalf("pausa dupla: %v", err)
+	}
+}
+
+func TestPreviousQuestionRecomputesScores(t *testing.T) {
+	type result struct{ score, streak int }
+	tests := []struct {
+		name string
+		// play joga a partida e volta uma pergunta
+		play      func(t *testing.T, r *Room)
+		wantIndex int
+		want      map[string]result
+	}{
+		{
+			name: "voltando com a pergunta aberta",
+			play: func(t *testing.T, r *Room) {
+				answerAndReveal(t, r, map[string]int{"p1": 0, "p2": 3}) // Só p1 acerta a primeira
+				nextQuestion(t, r)
+				if _, err := r.SubmitAnswer("p1", quiz.Response{Indexes: []int{1}}); err != nil {
+					t.Fatalf("SubmitAnswer: %v", err)
+				}
+			},
+			wantIndex: 0,
+			want:      map[string]result{"p1": {}, "p2": {}},
+		},
+		{
+			name: "voltando depois da revelação",
+			play: func(t *testing.T, r *Room) {
+				answerAndReveal(t, r, map[string]int{"p1": 0, "p2": 3})
+				nextQuestion(t, r)
+				answerAndReveal(t, r, map[string]int{"p1": 1, "p2": 1})
+			},
+			wantIndex: 0,
+			want:      map[string]result{"p1": {}, "p2": {}},
+		},
+		{
+			name: "aluno que entrou depois da rodada mantida",
+			play: func(t *testing.T, r *Room) {
+				answerAndReveal(t, r, map[string]int{"p1": 0, "p2": 3})
+				nextQuestion(t, r)
+				admitPlayers(t, r, "p3")
+				answerAndReveal(t, r, map[string]int{"p1": 1, "p2": 1, "p3": 1})
+				nextQuestion(t, r)
+			},
+			wantIndex: 1,
+			// p3 não estava na primeira pergunta: nem tempo acumulado nem sequência quebrada por ela
+			want: map[string]result{
+				"p1": {score: 10, streak: 1},
+				"p2": {score: 0, streak: 0},
+				"p3": {score: 0, streak: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := RoomSettings{LateJoin: EntradaTardiaZero}
+			r := newTestRoom(t, settings, quiz.PontuacaoFixa, choiceQuestion(0), choiceQuestion(1), choiceQuestion(2))
+			admitPlayers(t, r, "p1", "p2")
+			startGame(t, r)
+			tt.play(t, r)
+
+			if err := r.PreviousQuestion(); err != nil {
+				t.Fatalf("PreviousQuestion: %v", err)
+			}
+			if r.CurrentQuestionIndex != tt.wantIndex || r.Status != StateOpen {
+				t.Fatalf("pergunta %d em %s, esperado %d em OPEN", r.CurrentQuestionIndex, r.Status, tt.wantIndex)
+			}
+			if len(r.Answers) != 0 || len(r.Rounds) != tt.wantIndex+1 {
+				t.Fatalf("respostas = %d, rodadas = %d; esperado rodada nova e vazia", len(r.Answers), len(r.Rounds))
+			}
+			// Todos responderam na hora: só quem deixou uma rodada sem resposta somaria a janela inteira
+			window := answerWindow(choiceQuestion(0)).Milliseconds()
+			for id, w := range tt.want {
+				p := r.Players[id]
+				if p.Score != w.score || p.Streak != w.streak || p.LastPoints != 0 || p.AnswerTimeMs >= window {
+					t.Fatalf("%s após voltar: %+v, esperado %+v", id, *p, w)
+				}
+			}
+		})
+	}
+}
+
+// nextQuestion abre a pergunta seguinte.
+func nextQuestion(t *testing.T, r *Room) {
+	t.Helper()
+	if err := r.NextQuestion(); err != nil {
+		t.Fatalf("NextQuestion: %v", err)
+	}
+}
+
+func TestPreviousQuestionKeepsEarlierRounds(t *testing.T) {
+	r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoFixa, choiceQuestion(0), choiceQuestion(1), choiceQuestion(2))
+	admitPlayers(t, r, "p1", "p2")
+	startGame(t, r)
+
+	answerAndReveal(t, r, map[string]int{"p1": 0, "p2": 3})
+	r.NextQuestion()
+	answerAndReveal(t, r, map[string]int{"p1": 1, "p2": 1})
+	r.NextQuestion()
+
+	// Com sequência de 2 acertos, p1 tinha 10 + 11 pontos
+	if got := r.Players["p1"].Score; got != 21 {
+		t.Fatalf("pontuação antes de voltar = %d, esperado 21", got)
+	}
+
+	// Da terceira para a segunda: só a primeira rodada continua valendo
+	if err := r.PreviousQuestion(); err != nil {
+		t.Fatalf("PreviousQuestion: %v", err)
+	}
+	if r.CurrentQuestionIndex != 1 || len(r.Rounds) != 2 {
+		t.Fatalf("pergunta %d com %d rodadas, esperado 1 com 2", r.CurrentQuestionIndex, len(r.Rounds))
+	}
+
+	want := map[string]struct{ score, streak, rank int }{
+		"p1": {score: 10, streak: 1, rank: 1},
+		"p2": {score: 0, streak: 0, rank: 2},
+	}
+	for id, w := range want {
+		p := r.Players[id]
+		if p.Score != w.score || p.Streak != w.streak || p.Rank != w.rank || p.LastPoints != 0 {
+			t.Fatalf("%s = %+v, esperado pontuação %d, sequência %d, posição %d", id, *p, w.score, w.streak, w.rank)
+		}
+	}
+
+	if err := r.PreviousQuestion(); err != nil {
+		t.Fatalf("PreviousQuestion: %v", err)
+	}
+	if err := r.PreviousQuestion(); !errors.Is(err, ErrSemPerguntaAnterior) {
+		t.Fatalf("voltar da primeira pergunta: %v", err)
+	}
+}
+
+func TestSkipLastQuestionHidesAnswerKey(t *testing.T) {
+	numeric := 42.0
+	tests := []struct {
+		name     string
+		question quiz.Question
+	}{
+		{name: "múltipla escolha", question: choiceQuestion(2)},
+		{name: "texto aberto", question: quiz.Question{ID: "q-txt", Type: quiz.TipoTextoAberto, Prompt: "Capital", AcceptedAnswers: []string{"Brasília"}}},
+		{name: "numérica", question: quiz.Question{ID: "q-num", Type: quiz.TipoNumerico, Prompt: "Resposta", NumericAnswer: &numeric, Tolerance: 1}},
+		{name: "ordenação", question: quiz.Question{ID: "q-ord", Type: quiz.TipoOrdenacao, Prompt: "Ordene", Items: []string{"1", "2", "3", "4"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoFixa, tt.question)
+			admitPlayers(t, r, "p1")
+			startGame(t, r)
+
+			if _, err := r.SkipQuestion(); err != nil {
+				t.Fatalf("SkipQuestion: %v", err)
+			}
+			// A pergunta pulada continua atual no jogo finalizado e vai para a sala em question_opened
+			state := r.GetStateSnapshot()
+			if state.Status != StateFinished {
+				t.Fatalf("estado após pular a última = %s, esperado FINISHED", state.Status)
+			}
+			if state.CorrectIndexes != nil {
+				t.Fatalf("correctIndexes = %v, esperado nenhum", state.CorrectIndexes)
+			}
+			want := tt.question.PublicView(r.shuffles[0])
+			if state.CurrentQuestion == nil || !reflect.DeepEqual(*state.CurrentQuestion, want) {
+				t.Fatalf("pergunta enviada = %+v, esperado a versão sem gabarito %+v", state.CurrentQuestion, want)
+			}
+		})
+	}
+}
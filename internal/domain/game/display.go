@@ -0,0 +1,40 @@
+package game
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrCodigoTelaInvalido = errors.New("código de tela inválido")
+	ErrTelaSomenteLeitura = errors.New("a tela de projeção apenas acompanha o jogo")
+)
+
+// IssueDisplayCode gera um novo código de tela de projeção. O código anterior deixa de valer
+// para novas conexões.
+func (r *Room) IssueDisplayCode() (string, error) {
+	code, err := GenerateDisplayCode()
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	r.displayCode = code
+	return code, nil
+}
+
+// CheckDisplayCode valida o código apresentado por uma tela de projeção.
+func (r *Room) CheckDisplayCode(code string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if r.displayCode == "" || subtle.ConstantTimeCompare([]byte(code), []byte(r.displayCode)) != 1 {
+		return ErrCodigoTelaInvalido
+	}
+	return nil
+}
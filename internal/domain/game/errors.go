@@ -0,0 +1,35 @@
+package game
+
+// Error é um erro de domínio com código estável, enviado ao aluno para que o cliente
+// trate o caso sem depender do texto da mensagem.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Motivos para recusar a entrada de um aluno (evento join_rejected)
+var (
+	ErrEntradaTardiaNegada  = &Error{Code: "LATE_JOIN_DENIED", Message: "o jogo já começou e a sala não aceita novos alunos"}
+	ErrSalaLotada           = &Error{Code: "ROOM_FULL", Message: "a sala atingiu o limite de alunos"}
+	ErrApelidoEmUso         = &Error{Code: "NICKNAME_TAKEN", Message: "apelido já em uso na sala"}
+	ErrEntradaJogoEncerrado = &Error{Code: "GAME_FINISHED", Message: "o jogo já foi finalizado"}
+	ErrEntradaRecusada      = &Error{Code: "ENTRY_REJECTED", Message: "Entrada negada pelo professor"}
+)
+
+// ErrRespostaBloqueada recusa a troca de resposta quando a primeira é definitiva (evento answer_locked).
+var ErrRespostaBloqueada = &Error{Code: "ANSWER_LOCKED", Message: "sua resposta já foi registrada e não pode ser alterada"}
+
+// Motivos para recusar uma resposta (evento answer_rejected)
+var (
+	ErrRespostaMalformada     = &Error{Code: "MALFORMED_PAYLOAD", Message: "payload de resposta malformado"}
+	ErrAlternativaInexistente = &Error{Code: "INVALID_OPTION", Message: "alternativa inexistente nesta pergunta"}
+	ErrRespostaIncompativel   = &Error{Code: "INVALID_ANSWER", Message: "resposta incompatível com o tipo da pergunta"}
+	ErrRespostaSalaPausada    = &Error{Code: "GAME_PAUSED", Message: ErrSalaPausada.Error()}
+	ErrRespostaForaDaPergunta = &Error{Code: "QUESTION_NOT_OPEN", Message: ErrSalaNaoAberta.Error()}
+	ErrRespostaTempoEsgotado  = &Error{Code: "TIME_UP", Message: ErrTempoEsgotado.Error()}
+	ErrRespostaForaDaSala     = &Error{Code: "NOT_IN_ROOM", Message: "jogador não está na sala"}
+)
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"errors"
+	"rankit/internal/domain/quiz"
+	"testing"
+	"time"
+)
+
+func TestSubmitAnswerRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*Room)
+		playerID string
+		resp     quiz.Response
+		want     *Error
+	}{
+		{
+			name:     "pergunta não aberta",
+			setup:    func(r *Room) { r.Status = StateRevealed },
+			playerID: "p1",
+			resp:     quiz.Response{Indexes: []int{0}},
+			want:     ErrRespostaForaDaPergunta,
+		},
+		{
+			name:     "sala pausada vem antes de aluno desconhecido",
+			setup:    func(r *Room) { r.Status = StatePaused },
+			playerID: "desconhecido",
+			resp:     quiz.Response{Indexes: []int{0}},
+			want:     ErrRespostaSalaPausada,
+		},
+		{
+			name:     "tempo esgotado vem antes de alternativa inexistente",
+			setup:    func(r *Room) { r.Deadline = time.Now().Add(-time.Second) },
+			playerID: "p1",
+			resp:     quiz.Response{Indexes: []int{9}},
+			want:     ErrRespostaTempoEsgotado,
+		},
+		{
+			name:     "aluno fora da sala",
+			playerID: "desconhecido",
+			resp:     quiz.Response{Indexes: []int{0}},
+			want:     ErrRespostaForaDaSala,
+		},
+		{
+			name:     "alternativa inexistente",
+			playerID: "p1",
+			resp:     quiz.Response{Indexes: []int{4}},
+			want:     ErrAlternativaInexistente,
+		},
+		{
+			name:     "índice negativo",
+			playerID: "p1",
+			resp:     quiz.Response{Indexes: []int{-1}},
+			want:     ErrAlternativaInexistente,
+		},
+		{
+			name:     "texto em pergunta de alternativas",
+			playerID: "p1",
+			resp:     quiz.Response{Text: "A"},
+			want:     ErrRespostaIncompativel,
+		},
+		{
+			name:     "duas alternativas em múltipla escolha",
+			playerID: "p1",
+			resp:     quiz.Response{Indexes: []int{0, 1}},
+			want:     ErrRespostaIncompativel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoFixa, choiceQuestion(0))
+			admitPlayers(t, r, "p1")
+			startGame(t, r)
+			if tt.setup != nil {
+				tt.setup(r)
+			}
+
+			_, err := r.SubmitAnswer(tt.playerID, tt.resp)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.want)
+			}
+			if len(r.Answers) != 0 {
+				t.Fatalf("resposta recusada foi gravada: %+v", r.Answers)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package game
+
+import "sort"
+
+// PlayerResult é o resultado individual de um aluno na pergunta revelada,
+// enviado apenas ao próprio aluno.
+type PlayerResult struct {
+	PlayerID     string `json:"-"`
+	Answered     bool   `json:"answered"`
+	Correct      bool   `json:"correct"`
+	Points       int    `json:"points"` // Pontos ganhos nesta pergunta
+	Score        int    `json:"score"`  // Pontuação total
+	Streak       int    `json:"streak"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previousRank"`
+
+	CorrectIndexes []int `json:"correctIndexes,omitempty"` // Alternativas corretas, na ordem exibida ao aluno
+}
+
+// GetLeaderboard retorna o placar: pontuação (maior primeiro), depois menor tempo
+// acumulado de resposta. Apelido e ID desempatam o restante, para a ordem ser estável.
+func (r *Room) GetLeaderboard() []Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ranked := r.rankedPlayers()
+	board := make([]Player, 0, len(ranked))
+	for i, p := range ranked {
+		entry := *p
+		entry.Rank = i + 1
+		board = append(board, entry)
+	}
+	return board
+}
+
+// GetRoundResults retorna o resultado de cada aluno na pergunta atual (após a revelação).
+func (r *Room) GetRoundResults() []PlayerResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var correct []int
+	if r.CurrentQuestionIndex >= 0 && r.CurrentQuestionIndex < len(r.Quiz.Questions) {
+		correct = r.Quiz.Questions[r.CurrentQuestionIndex].CorrectIndexes
+	}
+
+	results := make([]PlayerResult, 0, len(r.Players))
+	for _, p := range r.Players {
+		res := PlayerResult{
+			PlayerID:     p.ID,
+			Points:       p.LastPoints,
+			Score:        p.Score,
+			Streak:       p.Streak,
+			Rank:         p.Rank,
+			PreviousRank: p.PreviousRank,
+		}
+		if len(correct) > 0 {
+			res.CorrectIndexes = r.toDisplayIndexes(p.ID, correct)
+		}
+		if ans, ok := r.Answers[p.ID]; ok {
+			res.Answered = true
+			res.Correct = ans.Correct
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// rankedPlayers ordena os jogadores pelo critério do placar. Deve ser chamado com o lock adquirido.
+func (r *Room) rankedPlayers() []*Player {
+	players := make([]*Player, 0, len(r.Players))
+	for _, p := range r.Players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.AnswerTimeMs != b.AnswerTimeMs {
+			return a.AnswerTimeMs < b.AnswerTimeMs
+		}
+		if a.Nickname != b.Nickname {
+			return a.Nickname < b.Nickname
+		}
+		return a.ID < b.ID
+	})
+	return players
+}
+
+// recordPreviousRanks guarda a posição de cada aluno antes da revelação. Deve ser chamado com o lock adquirido.
+func (r *Room) recordPreviousRanks() {
+	for i, p := range r.rankedPlayers() {
+		p.PreviousRank = i + 1
+	}
+}
+
+// updateRanks recalcula a posição de cada aluno. Deve ser chamado com o lock adquirido.
+func (r *Room) updateRanks() {
+	for i, p := range r.rankedPlayers() {
+		p.Rank = i + 1
+	}
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"math/rand/v2"
+	"rankit/internal/domain/quiz"
+)
+
+// ShuffleQuestions retorna uma cópia do quiz com as perguntas em ordem aleatória.
+// O quiz original (compartilhado com o repositório) não é alterado.
+func ShuffleQuestions(q *quiz.Quiz) *quiz.Quiz {
+	cp := *q
+	cp.Questions = make([]quiz.Question, len(q.Questions))
+	for i, idx := range rand.Perm(len(q.Questions)) {
+		cp.Questions[i] = q.Questions[idx]
+	}
+	return &cp
+}
+
+// PlayerOptions retorna as alternativas da pergunta atual na ordem exibida ao aluno,
+// ou nil se as alternativas não são embaralhadas para ele.
+func (r *Room) PlayerOptions(playerID string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.playerOptions(playerID)
+}
+
+// AllPlayerOptions retorna as alternativas exibidas a cada aluno (PlayerID -> alternativas).
+// Vazio quando a pergunta atual não embaralha as alternativas.
+func (r *Room) AllPlayerOptions() map[string][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views := make(map[string][]string)
+	for id := range r.Players {
+		if shown := r.playerOptions(id); shown != nil {
+			views[id] = shown
+		}
+	}
+	return views
+}
+
+// DisplayIndexes converte índices originais da pergunta atual nas posições exibidas ao aluno.
+func (r *Room) DisplayIndexes(playerID string, canonical []int) []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.toDisplayIndexes(playerID, canonical)
+}
+
+// shufflesOptions indica se as alternativas da pergunta são embaralhadas por aluno.
+// Verdadeiro ou falso mantém a ordem fixa.
+func (r *Room) shufflesOptions(q *quiz.Question) bool {
+	return r.Settings.ShuffleOptions && q.HasOptions() && q.Type != quiz.TipoVerdadeiroFalso
+}
+
+// assignOptionOrder sorteia a ordem das alternativas da pergunta atual para o aluno.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) assignOptionOrder(playerID string) {
+	if r.CurrentQuestionIndex < 0 || r.CurrentQuestionIndex >= len(r.Quiz.Questions) {
+		return
+	}
+	q := &r.Quiz.Questions[r.CurrentQuestionIndex]
+	if !r.shufflesOptions(q) {
+		return
+	}
+	orders, ok := r.optionOrders[r.CurrentQuestionIndex]
+	if !ok {
+		orders = make(map[string][]int)
+		r.optionOrders[r.CurrentQuestionIndex] = orders
+	}
+	if _, exists := orders[playerID]; !exists {
+		orders[playerID] = rand.Perm(len(q.Options))
+	}
+}
+
+// playerOptions monta as alternativas na ordem do aluno. Deve ser chamado com o lock adquirido.
+func (r *Room) playerOptions(playerID string) []string {
+	order := r.optionOrder(playerID)
+	if order == nil {
+		return nil
+	}
+	options := r.Quiz.Questions[r.CurrentQuestionIndex].Options
+	shown := make([]string, len(order))
+	for i, idx := range order {
+		shown[i] = options[idx]
+	}
+	return shown
+}
+
+// optionOrder retorna a ordem das alternativas da pergunta atual para o aluno
+// (posição exibida -> índice original). Deve ser chamado com o lock adquirido.
+func (r *Room) optionOrder(playerID string) []int {
+	return r.optionOrders[r.CurrentQuestionIndex][playerID]
+}
+
+// toCanonicalIndexes converte posições exibidas ao aluno nos índices originais.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) toCanonicalIndexes(playerID string, shown []int) []int {
+	order := r.optionOrder(playerID)
+	if order == nil {
+		return shown
+	}
+	canonical := make([]int, len(shown))
+	for i, pos := range shown {
+		canonical[i] = order[pos]
+	}
+	return canonical
+}
+
+// toDisplayIndexes converte índices originais nas posições exibidas ao aluno.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) toDisplayIndexes(playerID string, canonical []int) []int {
+	order := r.optionOrder(playerID)
+	if order == nil {
+		return canonical
+	}
+	position := make(map[int]int, len(order))
+	for pos, idx := range order {
+		position[idx] = pos
+	}
+	shown := make([]int, len(canonical))
+	for i, idx := range canonical {
+		shown[i] = position[idx]
+	}
+	return shown
+}
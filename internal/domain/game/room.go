@@ -2,24 +2,33 @@ package game
 
 import (
 	"errors"
+	"math"
 	"rankit/internal/domain/quiz"
+	"rankit/internal/domain/scoring"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 )
 
 // Estados da Sala (State Machine)
 const (
-	StateLobby    = "LOBBY"
-	StateOpen     = "OPEN"
-	StateRevealed = "REVEALED"
-	StateFinished = "FINISHED"
+	StateLobby     = "LOBBY"
+	StateStarted   = "STARTED" // Jogo iniciado (lobby travado), aguardando a primeira pergunta
+	StateOpen      = "OPEN"
+	StatePaused    = "PAUSED" // Pergunta aberta congelada: cronômetro parado e respostas recusadas
+	StateRevealed  = "REVEALED"
+	StateFinished  = "FINISHED"
+	StateAbandoned = "ABANDONED" // Encerrada (professor ou expiração) antes do fim do quiz
 )
 
 var (
-	ErrSalaIniciada       = errors.New("a sala já foi iniciada")
-	ErrSalaNaoAberta      = errors.New("a pergunta não está aberta para respostas")
-	ErrJogoFinalizado     = errors.New("o jogo já foi finalizado")
-	ErrPermissaoProfessor = errors.New("apenas o professor pode realizar esta ação")
+	ErrSalaIniciada         = errors.New("a sala já foi iniciada")
+	ErrSalaNaoAberta        = errors.New("a pergunta não está aberta para respostas")
+	ErrJogoFinalizado       = errors.New("o jogo já foi finalizado")
+	ErrPermissaoProfessor   = errors.New("apenas o professor pode realizar esta ação")
+	ErrTempoEsgotado        = errors.New("o tempo para responder esta pergunta acabou")
+	ErrJogadorNaoEncontrado = errors.New("jogador não encontrado na sala")
 )
 
 // Player representa um aluno na sala.
@@ -28,74 +37,147 @@ type Player struct {
 	Nickname  string `json:"nickname"`
 	Score     int    `json:"score"`
 	Connected bool   `json:"connected"`
+	TeamID    string `json:"teamId,omitempty"` // Time do aluno (modo times)
+
+	StartingScore int `json:"startingScore,omitempty"` // Pontuação inicial de quem entrou depois do início (lateJoin AVERAGE)
+
+	Streak     int `json:"streak"`     // Acertos consecutivos em perguntas pontuadas
+	LastPoints int `json:"lastPoints"` // Pontos ganhos na última pergunta revelada
+
+	AnswerTimeMs int64 `json:"answerTimeMs"` // Tempo acumulado de resposta nas perguntas pontuadas (desempate)
+	Rank         int   `json:"rank"`         // Posição no placar
+	PreviousRank int   `json:"previousRank"` // Posição antes da última revelação (0 = nenhuma revelação ainda)
 }
 
 // Answer representa a resposta de um aluno para a pergunta atual.
 type Answer struct {
-	PlayerID    string
-	AnswerIndex int // 0..3
-	SubmittedAt time.Time
+	PlayerID         string
+	Response         quiz.Response
+	SubmittedAt      time.Time     // Envio da resposta vigente
+	FirstSubmittedAt time.Time     // Primeiro envio na pergunta
+	Changes          int           // Quantas vezes o aluno trocou a resposta
+	Elapsed          time.Duration // Tempo de resposta da resposta vigente desde a abertura, descontando pausas
+	Credit           float64       // Fração correta da resposta, 0..1 (preenchido na revelação)
+	Correct          bool          // Totalmente correta (preenchido na revelação)
+	Points           int           // Pontos ganhos nesta pergunta, já com bônus de sequência e multiplicador (preenchido na revelação)
+	Streak           int           // Sequência de acertos do aluno após esta pergunta (preenchido na revelação)
+}
+
+// Round guarda o registro de respostas de uma pergunta já aberta na sala.
+type Round struct {
+	QuestionIndex int
+	OpenedAt      time.Time
+	RevealedAt    time.Time          // Zero se a pergunta não chegou a ser revelada
+	Skipped       bool               // Pulada pelo professor (não pontua)
+	Answers       map[string]*Answer // Map[PlayerID]*Answer
+	Participants  []string           // Alunos na sala quando a pergunta foi revelada (os pontuados nela)
+}
+
+// Revealed indica se a pergunta da rodada foi revelada (e pontuada).
+func (rd *Round) Revealed() bool {
+	return !rd.RevealedAt.IsZero()
 }
 
 // Room representa uma sala de aula ao vivo.
 // Mantém o estado do jogo em memória.
 type Room struct {
-	ID        string
+	ID        string // Identificador interno (UUID)
+	Code      string // Código numérico que os alunos digitam para entrar
 	TeacherID string
 	Quiz      *quiz.Quiz
+	Settings  RoomSettings
+	Teams     []*Team // Times, na ordem em que foram criados (modo times)
 
 	Status               string
 	CurrentQuestionIndex int
+	OpenedAt             time.Time // Momento em que a pergunta atual foi aberta (adiantado ao retomar uma pausa)
+	PausedAt             time.Time // Início da pausa atual (zero se não está pausada)
+	Deadline             time.Time // Prazo para respostas da pergunta atual (zero = sem limite)
 
 	PendingPlayers map[string]*Player // Map[SessionID]*Player (Aguardando aprovação)
 	Players        map[string]*Player // Map[SessionID]*Player (Aprovados)
 	Answers        map[string]*Answer // Map[PlayerID]*Answer (da pergunta atual)
-
-	mu sync.RWMutex // Mutex para garantir thread-safety
+	Rounds         []*Round           // Histórico de respostas por pergunta, na ordem em que foram abertas
+
+	CreatedAt      time.Time
+	StartedAt      time.Time // Início do jogo (teacher_start_game)
+	FinishedAt     time.Time // Fim do jogo
+	LastActivityAt time.Time // Última interação de professor ou aluno (usado na expiração)
+	Archived       bool      // Já foi salva no histórico
+
+	displayCode  string                   // Código que autoriza telas de projeção (fora do JSON público da sala)
+	shuffles     map[int][]int            // QuestionIndex -> ordem de exibição dos itens (ORDERING/MATCHING)
+	optionOrders map[int]map[string][]int // QuestionIndex -> PlayerID -> ordem das alternativas exibida ao aluno
+	version      int64                    // Incrementado a cada alteração (ordena snapshots persistidos)
+	scorer       scoring.Scorer           // Fórmula de pontuação (definida pelo modo do quiz)
+	timer        *time.Timer              // Cronômetro da pergunta atual
+	onDeadline   func()                   // Callback disparado quando o tempo da pergunta acaba
+	closed       bool                     // Encerrada por Close: o estado não é mais gravado
+	archiving    bool                     // Arquivamento reservado por TryClaimArchive e ainda em andamento
+	mu           sync.RWMutex             // Mutex para garantir thread-safety
 }
 
 // NewRoom cria uma nova sala.
-func NewRoom(id, teacherID string, q *quiz.Quiz) *Room {
+// As configurações devem ter passado por RoomSettings.Normalize.
+func NewRoom(id, code, teacherID string, q *quiz.Quiz, settings RoomSettings) *Room {
+	now := time.Now()
 	return &Room{
 		ID:                   id,
+		Code:                 code,
 		TeacherID:            teacherID,
 		Quiz:                 q,
+		Settings:             settings,
+		Teams:                newTeams(settings.TeamNames),
 		Status:               StateLobby,
 		CurrentQuestionIndex: -1, // Ainda não começou
 		Players:              make(map[string]*Player),
 		PendingPlayers:       make(map[string]*Player),
 		Answers:              make(map[string]*Answer),
+		shuffles:             make(map[int][]int),
+		optionOrders:         make(map[int]map[string][]int),
+		CreatedAt:            now,
+		LastActivityAt:       now,
+		scorer:               scoring.NewScorer(q.ScoringMode),
 	}
 }
 
+// OnDeadline registra o callback chamado quando o tempo de uma pergunta acaba.
+// O callback roda fora do lock da sala e deve usar o mesmo fluxo de revelação do professor.
+func (r *Room) OnDeadline(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onDeadline = fn
+}
+
 // --- Métodos de Controle do Jogo (State Machine) ---
 
-// JoinRequest adiciona um jogador à lista de pendentes.
+// JoinRequest adiciona um jogador à lista de pendentes (ou direto à sala, com autoApprove).
+// Depois do início, só aceita novos alunos se a sala permitir entrada tardia.
 func (r *Room) JoinRequest(sessionID, nickname string) (*Player, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
-	// 1. Bloqueio de entrada se sala já iniciada
-	if r.Status != StateLobby {
-		// Se já é player aprovado, permite reconexão (lógica abaixo)
-		if p, ok := r.Players[sessionID]; ok {
-			p.Connected = true
-			return p, nil
-		}
-		return nil, ErrSalaIniciada
-	}
-
-	// 2. Se já aprovado, retorna ok
+	// 1. Já aprovado: reconexão
 	if p, ok := r.Players[sessionID]; ok {
 		p.Connected = true
 		return p, nil
 	}
 
-	// 3. Adiciona aos pendentes
-	// Check duplicidade de nickname (opcional, mas bom)
+	// 2. Bloqueio de entrada conforme o estado e as regras da sala
+	switch {
+	case r.Status == StateFinished || r.Status == StateAbandoned:
+		return nil, ErrEntradaJogoEncerrado
+	case r.Status != StateLobby && !r.lateJoinAllowed():
+		return nil, ErrEntradaTardiaNegada
+	case r.full():
+		return nil, ErrSalaLotada
+	}
+
+	// 3. Check duplicidade de nickname
 	for _, p := range r.Players {
 		if p.Nickname == nickname {
-			return nil, errors.New("apelido já em uso na sala")
+			return nil, ErrApelidoEmUso
 		}
 	}
 
@@ -105,6 +187,10 @@ func (r *Room) JoinRequest(sessionID, nickname string) (*Player, error) {
 		Score:     0,
 		Connected: true,
 	}
+	if r.Settings.AutoApprove {
+		r.admit(p)
+		return p, nil
+	}
 	r.PendingPlayers[sessionID] = p
 	return p, nil
 }
@@ -113,6 +199,7 @@ func (r *Room) JoinRequest(sessionID, nickname string) (*Player, error) {
 func (r *Room) ApprovePlayer(sessionID string) (*Player, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
 	p, ok := r.PendingPlayers[sessionID]
 	if !ok {
@@ -122,16 +209,69 @@ func (r *Room) ApprovePlayer(sessionID string) (*Player, error) {
 		}
 		return nil, errors.New("jogador não encontrada na lista de pendentes")
 	}
+	if r.full() {
+		return nil, ErrSalaLotada
+	}
 
 	delete(r.PendingPlayers, sessionID)
-	r.Players[sessionID] = p
+	r.admit(p)
 	return p, nil
 }
 
+// IsPlayer indica se o jogador já foi aprovado na sala.
+func (r *Room) IsPlayer(playerID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.Players[playerID]
+	return ok
+}
+
+// admit coloca o jogador na sala. Deve ser chamado com o lock adquirido.
+func (r *Room) admit(p *Player) {
+	if r.Status != StateLobby && r.Settings.LateJoin == EntradaTardiaMedia {
+		// Calculada antes de incluir o próprio aluno
+		p.StartingScore = r.averageScore()
+		p.Score = p.StartingScore
+	}
+	r.Players[p.ID] = p
+	if r.Settings.TeamMode && r.Settings.AutoBalance && p.TeamID == "" {
+		r.autoAssignTeam(p)
+	}
+	if r.Status == StateOpen || r.Status == StatePaused {
+		// Aprovado com a pergunta já aberta: também recebe alternativas embaralhadas
+		r.assignOptionOrder(p.ID)
+	}
+}
+
+// lateJoinAllowed indica se a sala aceita alunos depois do início do jogo.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) lateJoinAllowed() bool {
+	return r.Settings.LateJoin == EntradaTardiaZero || r.Settings.LateJoin == EntradaTardiaMedia
+}
+
+// full indica se a sala atingiu o limite de alunos aprovados. Deve ser chamado com o lock adquirido.
+func (r *Room) full() bool {
+	return r.Settings.MaxPlayers > 0 && len(r.Players) >= r.Settings.MaxPlayers
+}
+
+// averageScore retorna a média (arredondada) da pontuação dos alunos aprovados.
+// Deve ser chamado com o lock adquirido.
+func (r *Room) averageScore() int {
+	if len(r.Players) == 0 {
+		return 0
+	}
+	total := 0
+	for _, p := range r.Players {
+		total += p.Score
+	}
+	return int(math.Round(float64(total) / float64(len(r.Players))))
+}
+
 // RejectPlayer remove o jogador da lista de pendentes.
 func (r *Room) RejectPlayer(sessionID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
 	if _, ok := r.PendingPlayers[sessionID]; !ok {
 		return errors.New("jogador não encontrado na lista de pendentes")
@@ -144,6 +284,7 @@ func (r *Room) RejectPlayer(sessionID string) error {
 func (r *Room) RemovePlayer(playerID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
 	if _, ok := r.Players[playerID]; !ok {
 		return errors.New("jogador não encontrado na sala")
@@ -156,6 +297,55 @@ func (r *Room) RemovePlayer(playerID string) error {
 	return nil
 }
 
+// Reconnection descreve o estado recuperado por um jogador que voltou à sala.
+type Reconnection struct {
+	Player  Player
+	Pending bool    // Ainda aguarda aprovação do professor
+	Answer  *Answer // Resposta já enviada para a pergunta atual (nil se não respondeu)
+}
+
+// Reconnect marca como conectado um jogador (aprovado ou pendente) que voltou à sala.
+func (r *Room) Reconnect(playerID string) (*Reconnection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if p, ok := r.PendingPlayers[playerID]; ok {
+		p.Connected = true
+		return &Reconnection{Player: *p, Pending: true}, nil
+	}
+
+	p, ok := r.Players[playerID]
+	if !ok {
+		return nil, ErrJogadorNaoEncontrado
+	}
+	p.Connected = true
+
+	rec := &Reconnection{Player: *p}
+	if ans, answered := r.Answers[playerID]; answered {
+		cp := *ans
+		rec.Answer = &cp
+	}
+	return rec, nil
+}
+
+// Disconnect marca o jogador como desconectado, preservando pontuação e respostas.
+func (r *Room) Disconnect(playerID string) (Player, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	p, ok := r.Players[playerID]
+	if !ok {
+		p, ok = r.PendingPlayers[playerID]
+	}
+	if !ok {
+		return Player{}, false
+	}
+	p.Connected = false
+	return *p, true
+}
+
 // Join (Legado/Direto) - Mantido para compatibilidade se necessário, ou removido/adaptado
 func (r *Room) Join(playerID, nickname string) (*Player, error) {
 	// Redireciona para JoinRequest por padrão, ou mantém lógica antiga
@@ -169,22 +359,61 @@ func (r *Room) Join(playerID, nickname string) (*Player, error) {
 func (r *Room) NextQuestion() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
-	if r.Status == StateFinished {
+	if r.Status == StateLobby {
+		return ErrJogoNaoIniciado
+	}
+	if r.Status == StateFinished || r.Status == StateAbandoned {
 		return ErrJogoFinalizado
 	}
+	if r.Status == StatePaused {
+		return ErrSalaPausada
+	}
+
+	r.advance()
+	return nil
+}
 
+// advance abre a pergunta seguinte à atual ou finaliza o jogo. Deve ser chamado com o lock adquirido.
+func (r *Room) advance() {
+	r.stopTimer()
+
+	now := time.Now()
 	nextIndex := r.CurrentQuestionIndex + 1
 	if nextIndex >= len(r.Quiz.Questions) {
 		r.Status = StateFinished
-		return nil
+		r.FinishedAt = now
+		r.Deadline = time.Time{}
+		return
 	}
 
 	r.CurrentQuestionIndex = nextIndex
 	r.Status = StateOpen
-	r.Answers = make(map[string]*Answer) // Limpa respostas da rodada anterior
+	r.OpenedAt = now
 
-	return nil
+	// Nova rodada: as respostas anteriores ficam preservadas em Rounds
+	round := &Round{
+		QuestionIndex: nextIndex,
+		OpenedAt:      now,
+		Answers:       make(map[string]*Answer),
+	}
+	r.Rounds = append(r.Rounds, round)
+	r.Answers = round.Answers
+
+	// Itens de ordenação/associação são exibidos embaralhados
+	if n := r.Quiz.Questions[nextIndex].ShuffleSize(); n > 0 {
+		r.shuffles[nextIndex] = quiz.NewShuffle(n)
+	}
+	for id := range r.Players {
+		r.assignOptionOrder(id)
+	}
+
+	r.Deadline = time.Time{}
+	if limit := r.Quiz.Questions[nextIndex].TimeLimitDuration(); limit > 0 {
+		r.Deadline = r.OpenedAt.Add(limit)
+		r.armTimer(nextIndex, limit)
+	}
 }
 
 // RevealQuestion revela a resposta da pergunta atual e calcula pontuação.
@@ -192,78 +421,307 @@ func (r *Room) NextQuestion() error {
 func (r *Room) RevealQuestion() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
+	if r.Status == StatePaused {
+		return ErrSalaPausada
+	}
 	if r.Status != StateOpen {
 		return errors.New("a pergunta não está aberta")
 	}
 
+	r.stopTimer()
+
 	currentQ := r.Quiz.Questions[r.CurrentQuestionIndex]
 
-	// Calcula pontuação
-	for _, ans := range r.Answers {
-		if ans.AnswerIndex == currentQ.CorrectIndex {
-			if p, exists := r.Players[ans.PlayerID]; exists {
-				p.Score += 10
-			}
+	// Calcula pontuação conforme o tempo de resposta (proporcional ao crédito em respostas parciais).
+	// Enquetes não pontuam nem afetam a sequência: a revelação apenas mostra a distribuição dos votos.
+	players := r.playerList()
+	r.recordPreviousRanks()
+	if currentQ.IsScored() {
+		r.scoreAnswers(currentQ, r.Answers, players)
+	} else {
+		for _, p := range players {
+			p.LastPoints = 0
 		}
 	}
+	r.updateRanks()
 
+	if round := r.currentRound(); round != nil {
+		round.RevealedAt = time.Now()
+		round.Participants = make([]string, 0, len(players))
+		for _, p := range players {
+			round.Participants = append(round.Participants, p.ID)
+		}
+	}
 	r.Status = StateRevealed
 	return nil
 }
 
-// SubmitAnswer registra a resposta de um aluno.
-func (r *Room) SubmitAnswer(playerID string, answerIndex int) error {
+// scoreAnswers corrige as respostas de uma rodada e atualiza pontuação e sequência
+// dos alunos que participaram dela. Deve ser chamado com o lock adquirido.
+func (r *Room) scoreAnswers(q quiz.Question, answers map[string]*Answer, players []*Player) {
+	multiplier := q.PointsMultiplier
+	if multiplier < quiz.MultiplicadorMinimo {
+		multiplier = quiz.MultiplicadorMinimo // Quiz salvo antes do multiplicador existir
+	}
+	window := answerWindow(q)
+
+	for _, ans := range answers {
+		ans.Credit = q.Grade(ans.Response)
+		ans.Correct = ans.Credit >= 1
+	}
+
+	for _, p := range players {
+		p.LastPoints = 0
+		ans, answered := answers[p.ID]
+
+		// Quem não respondeu conta a janela inteira no tempo acumulado
+		elapsed := window
+		if answered {
+			elapsed = ans.Elapsed
+		}
+		p.AnswerTimeMs += elapsed.Milliseconds()
+
+		if !answered || !ans.Correct {
+			p.Streak = 0 // Sem resposta, resposta errada ou parcial quebra a sequência
+		} else {
+			p.Streak++
+		}
+		if !answered || ans.Credit <= 0 {
+			continue
+		}
+
+		points := float64(r.scorer.Points(elapsed, window)) * ans.Credit
+		if ans.Correct {
+			points *= scoring.StreakMultiplier(p.Streak)
+		}
+		ans.Points = int(math.Round(points * float64(multiplier)))
+		ans.Streak = p.Streak
+		p.Score += ans.Points
+		p.LastPoints = ans.Points
+	}
+}
+
+// playerList retorna os alunos aprovados, em ordem de ID. Deve ser chamado com o lock adquirido.
+func (r *Room) playerList() []*Player {
+	players := make([]*Player, 0, len(r.Players))
+	for _, p := range r.Players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+	return players
+}
+
+// touch registra atividade na sala. Deve ser chamado com o lock adquirido.
+func (r *Room) touch() {
+	r.LastActivityAt = time.Now()
+	r.version++
+}
+
+// currentRound retorna a rodada da pergunta atual. Deve ser chamado com o lock adquirido.
+func (r *Room) currentRound() *Round {
+	if len(r.Rounds) == 0 {
+		return nil
+	}
+	return r.Rounds[len(r.Rounds)-1]
+}
+
+// answerWindow retorna a janela de resposta usada no cálculo por velocidade.
+func answerWindow(q quiz.Question) time.Duration {
+	if limit := q.TimeLimitDuration(); limit > 0 {
+		return limit
+	}
+	return scoring.DefaultAnswerWindow
+}
+
+// armTimer agenda o fim da pergunta `index`. Deve ser chamado com o lock adquirido.
+func (r *Room) armTimer(index int, d time.Duration) {
+	r.timer = time.AfterFunc(d, func() { r.expire(index) })
+}
+
+// stopTimer cancela o cronômetro da pergunta atual. Deve ser chamado com o lock adquirido.
+func (r *Room) stopTimer() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// expire é disparado pelo cronômetro. Ignora o disparo se a pergunta já foi revelada ou trocada.
+func (r *Room) expire(index int) {
+	r.mu.Lock()
+	if r.Status != StateOpen || r.CurrentQuestionIndex != index {
+		r.mu.Unlock()
+		return
+	}
+	r.timer = nil
+	fn := r.onDeadline
+	r.mu.Unlock()
+
+	if fn != nil {
+		fn()
+	}
+}
+
+// Submission é o resultado de uma resposta aceita, copiado enquanto a sala está travada.
+type Submission struct {
+	Answer        Answer // Resposta vigente do aluno
+	QuestionIndex int    // Pergunta respondida
+	AnswersCount  int    // Quantos alunos já responderam a pergunta
+	Distribution  []int  // Votos por alternativa (apenas enquetes)
+}
+
+// SubmitAnswer registra a resposta de um aluno e retorna a resposta vigente. Com FIRST_FINAL,
+// uma segunda resposta é recusada com ErrRespostaBloqueada; com CHANGEABLE, substitui a anterior.
+// Toda recusa é um *Error, com o código do motivo.
+func (r *Room) SubmitAnswer(playerID string, resp quiz.Response) (Submission, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.touch()
 
+	if r.Status == StatePaused {
+		return Submission{}, ErrRespostaSalaPausada
+	}
 	if r.Status != StateOpen {
-		return ErrSalaNaoAberta
+		return Submission{}, ErrRespostaForaDaPergunta
+	}
+
+	now := time.Now()
+	if !r.Deadline.IsZero() && now.After(r.Deadline) {
+		return Submission{}, ErrRespostaTempoEsgotado
 	}
 
 	if _, exists := r.Players[playerID]; !exists {
-		return errors.New("jogador não está na sala")
+		return Submission{}, ErrRespostaForaDaSala
+	}
+
+	prev, answered := r.Answers[playerID]
+	if answered && r.Settings.AnswerPolicy == RespostaDefinitiva {
+		return Submission{}, ErrRespostaBloqueada
+	}
+
+	q := &r.Quiz.Questions[r.CurrentQuestionIndex]
+	if err := checkResponse(q, resp); err != nil {
+		return Submission{}, err
 	}
+	// O aluno responde sobre os itens embaralhados; guardamos nos índices originais
+	resp = q.ToCanonical(resp, r.shuffles[r.CurrentQuestionIndex])
+	resp.Indexes = r.toCanonicalIndexes(playerID, resp.Indexes)
 
-	r.Answers[playerID] = &Answer{
-		PlayerID:    playerID,
-		AnswerIndex: answerIndex,
-		SubmittedAt: time.Now(),
+	if answered && reflect.DeepEqual(prev.Response, resp) {
+		return r.submission(q, prev), nil // Reenvio da mesma resposta não conta como troca
 	}
 
+	// A velocidade é medida pela resposta vigente: com troca livre, trocar custa o tempo já gasto
+	ans := &Answer{
+		PlayerID:         playerID,
+		Response:         resp,
+		SubmittedAt:      now,
+		FirstSubmittedAt: now,
+		Elapsed:          now.Sub(r.OpenedAt), // OpenedAt é adiantado ao retomar uma pausa
+	}
+	if answered {
+		ans.FirstSubmittedAt = prev.FirstSubmittedAt
+		ans.Changes = prev.Changes + 1
+	}
+	r.Answers[playerID] = ans
+
+	return r.submission(q, ans), nil
+}
+
+// submission copia a resposta e o andamento da pergunta. Deve ser chamado com o lock adquirido.
+func (r *Room) submission(q *quiz.Question, ans *Answer) Submission {
+	sub := Submission{
+		Answer:        *ans,
+		QuestionIndex: r.CurrentQuestionIndex,
+		AnswersCount:  len(r.Answers),
+	}
+	if q.Type == quiz.TipoEnquete {
+		sub.Distribution = r.distribution()
+	}
+	return sub
+}
+
+// checkResponse valida a resposta contra a pergunta atual: índices fora das alternativas
+// reais e formatos que não correspondem ao tipo da pergunta são recusados.
+func checkResponse(q *quiz.Question, resp quiz.Response) error {
+	if q.HasOptions() {
+		for _, idx := range resp.Indexes {
+			if idx < 0 || idx >= len(q.Options) {
+				return ErrAlternativaInexistente
+			}
+		}
+	}
+	if err := q.CheckResponse(resp); err != nil {
+		return ErrRespostaIncompativel
+	}
 	return nil
 }
 
-// StateSnapshot retorna o estado atual para enviar ao cliente.
+// RoomStateDTO é o estado atual da sala enviado aos clientes.
 type RoomStateDTO struct {
 	Status               string         `json:"status"`
 	CurrentQuestion      *quiz.Question `json:"currentQuestion,omitempty"`
 	TotalQuestions       int            `json:"totalQuestions"`
 	CurrentQuestionIndex int            `json:"currentQuestionIndex"`
 	PlayersCount         int            `json:"playersCount"`
-	AnswersCount         int            `json:"answersCount"`           // Quantos responderam
-	CorrectIndex         int            `json:"correctIndex,omitempty"` // Só enviado se REVEALED
+	AnswersCount         int            `json:"answersCount"`             // Quantos responderam
+	CorrectIndexes       []int          `json:"correctIndexes,omitempty"` // Só enviado se REVEALED, e nunca no estado público
+	Distribution         []int          `json:"distribution,omitempty"`   // Votos por alternativa (enquetes)
+	Deadline             *time.Time     `json:"deadline,omitempty"`       // Prazo da pergunta aberta (se houver limite)
+	RemainingMs          int64          `json:"remainingMs,omitempty"`    // Tempo restante congelado (sala pausada com limite)
+	Teams                []Team         `json:"teams,omitempty"`          // Times da sala (modo times)
+	ServerTime           time.Time      `json:"serverTime"`               // Relógio do servidor, para sincronizar a contagem regressiva
 }
 
+// GetStateSnapshot retorna o estado completo da sala: após a revelação inclui o gabarito,
+// com os índices originais das alternativas. Destinado ao professor e à tela de projeção.
 func (r *Room) GetStateSnapshot() RoomStateDTO {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.stateSnapshot(false)
+}
 
+// GetPublicStateSnapshot retorna o estado enviado aos alunos: a pergunta nunca leva o gabarito.
+// Na revelação, cada aluno recebe as alternativas corretas no próprio your_result, na ordem em
+// que as viu.
+func (r *Room) GetPublicStateSnapshot() RoomStateDTO {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.stateSnapshot(true)
+}
+
+// stateSnapshot monta o estado da sala. Deve ser chamado com o lock adquirido.
+func (r *Room) stateSnapshot(public bool) RoomStateDTO {
 	var currentQ *quiz.Question
-	correctIndex := -1
+	var correctIndexes, distribution []int
 
 	if r.CurrentQuestionIndex >= 0 && r.CurrentQuestionIndex < len(r.Quiz.Questions) {
 		q := r.Quiz.Questions[r.CurrentQuestionIndex]
-		// Clona para não expor correct index se OPEN
+		// O gabarito só acompanha a pergunta revelada: uma pergunta pulada continua atual
+		// quando o jogo termina (FINISHED) e nunca foi revelada
 		qCopy := q
-		if r.Status == StateOpen {
-			qCopy.CorrectIndex = -1
-		} else if r.Status == StateRevealed {
-			correctIndex = q.CorrectIndex
+		if public || r.Status != StateRevealed {
+			qCopy = q.PublicView(r.shuffles[r.CurrentQuestionIndex])
+		} else {
+			correctIndexes = q.CorrectIndexes
+		}
+		if q.Type == quiz.TipoEnquete {
+			distribution = r.distribution()
 		}
 		currentQ = &qCopy
 	}
 
+	var deadline *time.Time
+	var remaining int64
+	if r.Status == StateOpen && !r.Deadline.IsZero() {
+		d := r.Deadline
+		deadline = &d
+	} else if r.Status == StatePaused && !r.Deadline.IsZero() {
+		remaining = r.Deadline.Sub(r.PausedAt).Milliseconds()
+	}
+
 	return RoomStateDTO{
 		Status:               r.Status,
 		CurrentQuestion:      currentQ,
@@ -271,20 +729,295 @@ func (r *Room) GetStateSnapshot() RoomStateDTO {
 		CurrentQuestionIndex: r.CurrentQuestionIndex,
 		PlayersCount:         len(r.Players),
 		AnswersCount:         len(r.Answers),
-		CorrectIndex:         correctIndex,
+		CorrectIndexes:       correctIndexes,
+		Distribution:         distribution,
+		Deadline:             deadline,
+		RemainingMs:          remaining,
+		Teams:                r.copyTeams(),
+		ServerTime:           time.Now(),
+	}
+}
+
+// PublicRoom são os dados da sala expostos na API pública (GET /rooms/{id}): sem gabarito,
+// sem as respostas dos alunos e sem os IDs de conexão.
+type PublicRoom struct {
+	ID                   string         `json:"id"`
+	Code                 string         `json:"code"`
+	Status               string         `json:"status"`
+	Settings             RoomSettings   `json:"settings"`
+	TotalQuestions       int            `json:"totalQuestions"`
+	CurrentQuestionIndex int            `json:"currentQuestionIndex"`
+	CurrentQuestion      *quiz.Question `json:"currentQuestion,omitempty"` // Sempre sem gabarito
+	Players              []PublicPlayer `json:"players"`                   // Na ordem do placar
+	Teams                []Team         `json:"teams,omitempty"`
+	CreatedAt            time.Time      `json:"createdAt"`
+}
+
+// PublicPlayer identifica um aluno apenas pelo apelido. É a versão do jogador enviada aos
+// alunos e às telas de projeção: o ID de conexão fica restrito ao professor.
+type PublicPlayer struct {
+	Nickname     string `json:"nickname"`
+	Score        int    `json:"score"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previousRank"`
+	TeamID       string `json:"teamId,omitempty"`
+	Streak       int    `json:"streak"`
+}
+
+// Public retorna a versão pública do jogador.
+func (p Player) Public() PublicPlayer {
+	return PublicPlayer{
+		Nickname:     p.Nickname,
+		Score:        p.Score,
+		Rank:         p.Rank,
+		PreviousRank: p.PreviousRank,
+		TeamID:       p.TeamID,
+		Streak:       p.Streak,
+	}
+}
+
+// PublicPlayers converte um placar (ou pódio) para a versão pública, mantendo a ordem.
+func PublicPlayers(players []Player) []PublicPlayer {
+	public := make([]PublicPlayer, 0, len(players))
+	for _, p := range players {
+		public = append(public, p.Public())
 	}
+	return public
 }
 
-// GetLeaderboard retorna a lista de jogadores ordenada por score.
-func (r *Room) GetLeaderboard() []*Player {
+// GetPublicInfo copia sob lock os dados públicos da sala.
+func (r *Room) GetPublicInfo() PublicRoom {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	players := make([]*Player, 0, len(r.Players))
+	info := PublicRoom{
+		ID:                   r.ID,
+		Code:                 r.Code,
+		Status:               r.Status,
+		Settings:             r.Settings,
+		TotalQuestions:       len(r.Quiz.Questions),
+		CurrentQuestionIndex: r.CurrentQuestionIndex,
+		Players:              make([]PublicPlayer, 0, len(r.Players)),
+		Teams:                r.copyTeams(),
+		CreatedAt:            r.CreatedAt,
+	}
+	info.Settings.TeamNames = append([]string(nil), r.Settings.TeamNames...)
+	if i := r.CurrentQuestionIndex; i >= 0 && i < len(r.Quiz.Questions) {
+		q := r.Quiz.Questions[i].PublicView(r.shuffles[i])
+		info.CurrentQuestion = &q
+	}
+	for i, p := range r.rankedPlayers() {
+		entry := p.Public()
+		entry.Rank = i + 1
+		info.Players = append(info.Players, entry)
+	}
+	return info
+}
+
+// CurrentQuestion retorna uma cópia da pergunta atual (nil antes da primeira ou após a última).
+func (r *Room) CurrentQuestion() *quiz.Question {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.CurrentQuestionIndex < 0 || r.CurrentQuestionIndex >= len(r.Quiz.Questions) {
+		return nil
+	}
+	q := r.Quiz.Questions[r.CurrentQuestionIndex]
+	return &q
+}
+
+// Distribution retorna quantas respostas cada alternativa da pergunta atual recebeu.
+func (r *Room) Distribution() []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.distribution()
+}
+
+// distribution conta as respostas por alternativa. Deve ser chamado com o lock adquirido.
+func (r *Room) distribution() []int {
+	if r.CurrentQuestionIndex < 0 || r.CurrentQuestionIndex >= len(r.Quiz.Questions) {
+		return nil
+	}
+	counts := make([]int, len(r.Quiz.Questions[r.CurrentQuestionIndex].Options))
+	for _, ans := range r.Answers {
+		for _, idx := range ans.Response.Indexes {
+			if idx >= 0 && idx < len(counts) {
+				counts[idx]++
+			}
+		}
+	}
+	return counts
+}
+
+// AnswerStats é o andamento das respostas da pergunta atual, visível apenas ao professor.
+type AnswerStats struct {
+	QuestionIndex int             `json:"questionIndex"`
+	AnswersCount  int             `json:"answersCount"`
+	PlayersCount  int             `json:"playersCount"`
+	Distribution  []int           `json:"distribution,omitempty"` // Respostas por alternativa (índices originais); só em perguntas com alternativas
+	Answered      []AnswerStatus  `json:"answered"`
+	Pending       []PendingPlayer `json:"pending"`
+}
+
+// AnswerStatus identifica um aluno que já respondeu.
+type AnswerStatus struct {
+	PlayerID string `json:"playerId"`
+	Nickname string `json:"nickname"`
+	Changes  int    `json:"changes"`
+}
+
+// PendingPlayer identifica um aluno que ainda não respondeu.
+type PendingPlayer struct {
+	PlayerID  string `json:"playerId"`
+	Nickname  string `json:"nickname"`
+	Connected bool   `json:"connected"`
+}
+
+// GetAnswerStats retorna quem já respondeu, quem falta e a distribuição por alternativa.
+func (r *Room) GetAnswerStats() AnswerStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := AnswerStats{
+		QuestionIndex: r.CurrentQuestionIndex,
+		AnswersCount:  len(r.Answers),
+		PlayersCount:  len(r.Players),
+		Answered:      []AnswerStatus{},
+		Pending:       []PendingPlayer{},
+	}
+	if i := r.CurrentQuestionIndex; i >= 0 && i < len(r.Quiz.Questions) && r.Quiz.Questions[i].HasOptions() {
+		stats.Distribution = r.distribution()
+	}
 	for _, p := range r.Players {
-		players = append(players, p)
+		if ans, ok := r.Answers[p.ID]; ok {
+			stats.Answered = append(stats.Answered, AnswerStatus{PlayerID: p.ID, Nickname: p.Nickname, Changes: ans.Changes})
+		} else {
+			stats.Pending = append(stats.Pending, PendingPlayer{PlayerID: p.ID, Nickname: p.Nickname, Connected: p.Connected})
+		}
 	}
-	// A ordenação será feita no UseCase ou aqui?
-	// Vamos deixar array simples aqui, ordenação pode ser feita no output.
-	return players
+	sort.Slice(stats.Answered, func(i, j int) bool { return stats.Answered[i].Nickname < stats.Answered[j].Nickname })
+	sort.Slice(stats.Pending, func(i, j int) bool { return stats.Pending[i].Nickname < stats.Pending[j].Nickname })
+	return stats
+}
+
+// RoomResults é uma cópia consistente do estado da sala, usada para arquivamento.
+type RoomResults struct {
+	Status     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Players    []Player
+	Rounds     []Round
+	Teams      []TeamStanding // Vazio fora do modo times
+}
+
+// GetResults copia jogadores e rodadas sob lock, para leitura fora da sala.
+func (r *Room) GetResults() RoomResults {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := RoomResults{
+		Status:     r.Status,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		Players:    make([]Player, 0, len(r.Players)),
+		Rounds:     make([]Round, 0, len(r.Rounds)),
+	}
+	for _, p := range r.rankedPlayers() {
+		res.Players = append(res.Players, *p)
+	}
+	for _, rd := range r.Rounds {
+		res.Rounds = append(res.Rounds, copyRound(rd))
+	}
+	if r.Settings.TeamMode {
+		res.Teams = r.teamStandings()
+	}
+	return res
+}
+
+// --- Ciclo de vida ---
+
+// Expired indica se a sala passou do tempo ocioso ou do tempo máximo de vida.
+func (r *Room) Expired(now time.Time, idleTTL, maxTTL time.Duration) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if idleTTL > 0 && now.Sub(r.LastActivityAt) > idleTTL {
+		return true
+	}
+	return maxTTL > 0 && now.Sub(r.CreatedAt) > maxTTL
+}
+
+// Started indica se StartGame foi chamado (mesmo que nenhuma pergunta tenha sido aberta).
+func (r *Room) Started() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.StartedAt.IsZero()
+}
+
+// IsArchived indica se a sala já foi salva no histórico.
+func (r *Room) IsArchived() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.Archived
+}
+
+// TryClaimArchive reserva o arquivamento da sala para um único chamador. Retorna false se a
+// sala já foi arquivada ou se outro arquivamento está em andamento. Quem reservou termina com
+// MarkArchived ou, se falhar, devolve a reserva com ReleaseArchive.
+func (r *Room) TryClaimArchive() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Archived || r.archiving {
+		return false
+	}
+	r.archiving = true
+	return true
+}
+
+// ReleaseArchive desfaz a reserva de um arquivamento que falhou.
+func (r *Room) ReleaseArchive() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.archiving = false
+}
+
+// MarkArchived registra que a sala foi salva no histórico.
+func (r *Room) MarkArchived() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.version++
+	r.archiving = false
+	r.Archived = true
+}
+
+// Abandon encerra uma sala que não chegou ao fim do quiz. Salas finalizadas não são alteradas.
+func (r *Room) Abandon() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopTimer()
+	if r.Status == StateFinished || r.Status == StateAbandoned {
+		return
+	}
+	r.touch()
+	r.Status = StateAbandoned
+	r.FinishedAt = time.Now()
+	r.Deadline = time.Time{}
+}
+
+// Close libera os recursos da sala (cronômetro) antes de removê-la do repositório.
+// Depois disso a sala não volta a ser gravada (ver Closed).
+func (r *Room) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopTimer()
+	r.onDeadline = nil
+	r.closed = true
+}
+
+// Closed indica se a sala já foi encerrada por Close.
+func (r *Room) Closed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.closed
 }
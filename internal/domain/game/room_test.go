@@ -0,0 +1,152 @@
+package game
+
+import (
+	"errors"
+	"rankit/internal/domain/quiz"
+	"sync"
+	"testing"
+)
+
+// newTestRoom cria uma sala em memória com as perguntas informadas.
+func newTestRoom(t *testing.T, settings RoomSettings, scoringMode string, questions ...quiz.Question) *Room {
+	t.Helper()
+	if err := settings.Normalize(); err != nil {
+		t.Fatalf("configurações inválidas: %v", err)
+	}
+	q := &quiz.Quiz{
+		ID:          "quiz-1",
+		TeacherID:   "teacher-1",
+		Status:      quiz.StatusPublicado,
+		ScoringMode: scoringMode,
+		Questions:   questions,
+	}
+	return NewRoom("room-1", "123456", "teacher-1", q, settings)
+}
+
+// choiceQuestion cria uma pergunta de múltipla escolha com quatro alternativas.
+func choiceQuestion(correct int) quiz.Question {
+	return quiz.Question{
+		ID:             "q",
+		Type:           quiz.TipoMultiplaEscolha,
+		Prompt:         "Pergunta",
+		Options:        []string{"A", "B", "C", "D"},
+		CorrectIndexes: []int{correct},
+	}
+}
+
+// admitPlayers aprova os alunos na sala.
+func admitPlayers(t *testing.T, r *Room, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if _, err := r.JoinRequest(id, "aluno-"+id); err != nil {
+			t.Fatalf("JoinRequest(%s): %v", id, err)
+		}
+		if _, err := r.ApprovePlayer(id); err != nil {
+			t.Fatalf("ApprovePlayer(%s): %v", id, err)
+		}
+	}
+}
+
+// startGame inicia o jogo e abre a primeira pergunta.
+func startGame(t *testing.T, r *Room) {
+	t.Helper()
+	if _, err := r.StartGame(false); err != nil {
+		t.Fatalf("StartGame: %v", err)
+	}
+	if err := r.NextQuestion(); err != nil {
+		t.Fatalf("NextQuestion: %v", err)
+	}
+}
+
+func TestSubmitAnswerPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		second      []int
+		wantErr     error
+		wantIndexes []int
+		wantChanges int
+	}{
+		{name: "CHANGEABLE troca a resposta", policy: RespostaAlteravel, second: []int{2}, wantIndexes: []int{2}, wantChanges: 1},
+		{name: "CHANGEABLE reenvio igual não conta troca", policy: RespostaAlteravel, second: []int{1}, wantIndexes: []int{1}, wantChanges: 0},
+		{name: "FIRST_FINAL recusa a troca", policy: RespostaDefinitiva, second: []int{2}, wantErr: ErrRespostaBloqueada, wantIndexes: []int{1}},
+		{name: "FIRST_FINAL recusa até o reenvio igual", policy: RespostaDefinitiva, second: []int{1}, wantErr: ErrRespostaBloqueada, wantIndexes: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(t, RoomSettings{AnswerPolicy: tt.policy}, quiz.PontuacaoFixa, choiceQuestion(0))
+			admitPlayers(t, r, "p1", "p2")
+			startGame(t, r)
+
+			first, err := r.SubmitAnswer("p1", quiz.Response{Indexes: []int{1}})
+			if err != nil {
+				t.Fatalf("primeira resposta: %v", err)
+			}
+			if first.QuestionIndex != 0 || first.AnswersCount != 1 {
+				t.Fatalf("submissão = %+v, esperado pergunta 0 com 1 resposta", first)
+			}
+
+			_, err = r.SubmitAnswer("p1", quiz.Response{Indexes: tt.second})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+			}
+
+			ans := r.Answers["p1"]
+			if len(ans.Response.Indexes) != 1 || ans.Response.Indexes[0] != tt.wantIndexes[0] {
+				t.Fatalf("resposta vigente = %v, esperado %v", ans.Response.Indexes, tt.wantIndexes)
+			}
+			if ans.Changes != tt.wantChanges {
+				t.Fatalf("trocas = %d, esperado %d", ans.Changes, tt.wantChanges)
+			}
+			if !ans.FirstSubmittedAt.Equal(first.Answer.FirstSubmittedAt) {
+				t.Fatal("a troca não pode alterar o horário do primeiro envio")
+			}
+
+			sub, err := r.SubmitAnswer("p2", quiz.Response{Indexes: []int{0}})
+			if err != nil {
+				t.Fatalf("resposta do segundo aluno: %v", err)
+			}
+			if sub.AnswersCount != 2 {
+				t.Fatalf("contagem = %d, esperado 2", sub.AnswersCount)
+			}
+		})
+	}
+}
+
+func TestTryClaimArchive(t *testing.T) {
+	r := newTestRoom(t, RoomSettings{}, quiz.PontuacaoFixa, choiceQuestion(0))
+
+	// Fim de jogo e encerramento da sala disputando o arquivamento
+	const callers = 8
+	claims := make(chan bool, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claims <- r.TryClaimArchive()
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	won := 0
+	for ok := range claims {
+		if ok {
+			won++
+		}
+	}
+	if won != 1 {
+		t.Fatalf("%d chamadores reservaram o arquivamento, esperado 1", won)
+	}
+
+	// Falha ao salvar o histórico devolve a reserva
+	r.ReleaseArchive()
+	if !r.TryClaimArchive() {
+		t.Fatal("reserva não foi devolvida após a falha")
+	}
+	r.MarkArchived()
+	if r.TryClaimArchive() {
+		t.Fatal("sala já arquivada foi reservada de novo")
+	}
+}
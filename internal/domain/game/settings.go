@@ -0,0 +1,81 @@
+package game
+
+import "errors"
+
+// Agregação da pontuação dos times
+const (
+	PontuacaoTimeSoma  = "SUM"     // Soma dos pontos dos membros
+	PontuacaoTimeMedia = "AVERAGE" // Média dos pontos dos membros (times de tamanhos diferentes)
+)
+
+// Entrada de alunos depois do início do jogo
+const (
+	EntradaTardiaNegada = "DENY"    // Só entra quem estava no lobby
+	EntradaTardiaZero   = "ZERO"    // Entra com pontuação zero
+	EntradaTardiaMedia  = "AVERAGE" // Entra com a média da pontuação dos alunos da sala
+)
+
+// Troca de resposta durante a pergunta
+const (
+	RespostaAlteravel  = "CHANGEABLE"  // Pode trocar até a revelação ou o fim do tempo (vale a última)
+	RespostaDefinitiva = "FIRST_FINAL" // A primeira resposta é definitiva
+)
+
+var (
+	ErrPoliticaRespostaInvalida = errors.New("política de resposta inválida (use CHANGEABLE ou FIRST_FINAL)")
+	ErrPontuacaoTimeInvalida    = errors.New("pontuação de time inválida (use SUM ou AVERAGE)")
+	ErrEntradaTardiaInvalida    = errors.New("entrada tardia inválida (use DENY, ZERO ou AVERAGE)")
+	ErrLimiteJogadores          = errors.New("o limite de alunos não pode ser negativo")
+)
+
+// RoomSettings são as opções escolhidas pelo professor ao criar a sala.
+type RoomSettings struct {
+	TeamMode    bool     `json:"teamMode"`         // Jogo em times
+	TeamScoring string   `json:"teamScoring"`      // SUM (padrão) ou AVERAGE
+	AutoBalance bool     `json:"autoBalanceTeams"` // Distribui os alunos aprovados no time com menos membros
+	TeamNames   []string `json:"teams"`            // Times iniciais (podem ser redefinidos no lobby)
+
+	ShuffleQuestions bool `json:"shuffleQuestions"` // Perguntas em ordem aleatória (a mesma para toda a sala)
+	ShuffleOptions   bool `json:"shuffleOptions"`   // Alternativas em ordem aleatória para cada aluno
+
+	LateJoin    string `json:"lateJoin"`    // DENY (padrão), ZERO ou AVERAGE
+	MaxPlayers  int    `json:"maxPlayers"`  // Limite de alunos aprovados (0 = sem limite)
+	AutoApprove bool   `json:"autoApprove"` // Aprova a entrada sem moderação do professor
+
+	AnswerPolicy string `json:"answerPolicy"` // CHANGEABLE (padrão) ou FIRST_FINAL
+}
+
+// Normalize aplica os valores padrão e valida as opções.
+func (s *RoomSettings) Normalize() error {
+	switch s.LateJoin {
+	case "":
+		s.LateJoin = EntradaTardiaNegada
+	case EntradaTardiaNegada, EntradaTardiaZero, EntradaTardiaMedia:
+	default:
+		return ErrEntradaTardiaInvalida
+	}
+	if s.MaxPlayers < 0 {
+		return ErrLimiteJogadores
+	}
+	switch s.AnswerPolicy {
+	case "":
+		s.AnswerPolicy = RespostaAlteravel
+	case RespostaAlteravel, RespostaDefinitiva:
+	default:
+		return ErrPoliticaRespostaInvalida
+	}
+
+	if !s.TeamMode {
+		// Sem modo times, as demais opções de time não se aplicam
+		s.TeamScoring, s.AutoBalance, s.TeamNames = "", false, nil
+		return nil
+	}
+
+	if s.TeamScoring == "" {
+		s.TeamScoring = PontuacaoTimeSoma
+	}
+	if s.TeamScoring != PontuacaoTimeSoma && s.TeamScoring != PontuacaoTimeMedia {
+		return ErrPontuacaoTimeInvalida
+	}
+	return validateTeamNames(s.TeamNames)
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"rankit/internal/domain/quiz"
+	"time"
+)
+
+// RoomSnapshot é o estado serializável de uma sala, usado para sobreviver a reinícios do servidor.
+type RoomSnapshot struct {
+	Version              int64
+	ID                   string
+	Code                 string
+	TeacherID            string
+	Quiz                 *quiz.Quiz
+	Settings             RoomSettings
+	Teams                []Team
+	Status               string
+	CurrentQuestionIndex int
+	OpenedAt             time.Time
+	PausedAt             time.Time
+	Deadline             time.Time
+	PendingPlayers       []Player
+	Players              []Player
+	Rounds               []Round
+	Shuffles             map[int][]int
+	OptionOrders         map[int]map[string][]int
+	DisplayCode          string
+	CreatedAt            time.Time
+	StartedAt            time.Time
+	FinishedAt           time.Time
+	LastActivityAt       time.Time
+	Archived             bool
+}
+
+// Snapshot copia o estado da sala sob lock.
+func (r *Room) Snapshot() RoomSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := RoomSnapshot{
+		Version:              r.version,
+		ID:                   r.ID,
+		Code:                 r.Code,
+		TeacherID:            r.TeacherID,
+		Quiz:                 r.Quiz,
+		Settings:             r.Settings,
+		Teams:                r.copyTeams(),
+		Status:               r.Status,
+		CurrentQuestionIndex: r.CurrentQuestionIndex,
+		OpenedAt:             r.OpenedAt,
+		PausedAt:             r.PausedAt,
+		Deadline:             r.Deadline,
+		PendingPlayers:       make([]Player, 0, len(r.PendingPlayers)),
+		Players:              make([]Player, 0, len(r.Players)),
+		Rounds:               make([]Round, 0, len(r.Rounds)),
+		Shuffles:             make(map[int][]int, len(r.shuffles)),
+		OptionOrders:         make(map[int]map[string][]int, len(r.optionOrders)),
+		DisplayCode:          r.displayCode,
+		CreatedAt:            r.CreatedAt,
+		StartedAt:            r.StartedAt,
+		FinishedAt:           r.FinishedAt,
+		LastActivityAt:       r.LastActivityAt,
+		Archived:             r.Archived,
+	}
+	for _, p := range r.PendingPlayers {
+		s.PendingPlayers = append(s.PendingPlayers, *p)
+	}
+	for _, p := range r.Players {
+		s.Players = append(s.Players, *p)
+	}
+	for _, rd := range r.Rounds {
+		s.Rounds = append(s.Rounds, copyRound(rd))
+	}
+	for idx, perm := range r.shuffles {
+		s.Shuffles[idx] = perm
+	}
+	for idx, orders := range r.optionOrders {
+		cp := make(map[string][]int, len(orders))
+		for id, perm := range orders {
+			cp[id] = perm
+		}
+		s.OptionOrders[idx] = cp
+	}
+	return s
+}
+
+// RestoreRoom reconstrói uma sala a partir do snapshot. Todos os jogadores voltam
+// desconectados (reconectam com o token) e o cronômetro só é retomado via RestartTimer.
+func RestoreRoom(s RoomSnapshot) *Room {
+	r := NewRoom(s.ID, s.Code, s.TeacherID, s.Quiz, s.Settings)
+	r.Teams = make([]*Team, 0, len(s.Teams))
+	for _, t := range s.Teams {
+		t := t
+		r.Teams = append(r.Teams, &t)
+	}
+	r.version = s.Version
+	r.Status = s.Status
+	r.CurrentQuestionIndex = s.CurrentQuestionIndex
+	r.OpenedAt = s.OpenedAt
+	r.PausedAt = s.PausedAt
+	r.Deadline = s.Deadline
+	r.CreatedAt = s.CreatedAt
+	r.StartedAt = s.StartedAt
+	r.FinishedAt = s.FinishedAt
+	r.LastActivityAt = s.LastActivityAt
+	r.Archived = s.Archived
+	r.displayCode = s.DisplayCode
+
+	for _, p := range s.PendingPlayers {
+		p := p
+		p.Connected = false
+		r.PendingPlayers[p.ID] = &p
+	}
+	for _, p := range s.Players {
+		p := p
+		p.Connected = false
+		r.Players[p.ID] = &p
+	}
+	for _, rd := range s.Rounds {
+		cp := copyRound(&rd)
+		r.Rounds = append(r.Rounds, &cp)
+	}
+	for idx, perm := range s.Shuffles {
+		r.shuffles[idx] = perm
+	}
+	for idx, orders := range s.OptionOrders {
+		r.optionOrders[idx] = orders
+	}
+
+	// Answers aponta para a rodada da pergunta atual
+	if round := r.currentRound(); round != nil && round.QuestionIndex == r.CurrentQuestionIndex {
+		r.Answers = round.Answers
+	}
+	return r
+}
+
+// RestartTimer retoma o cronômetro de uma pergunta aberta (ex: após restaurar a sala).
+// Se o prazo já passou, o fim do tempo é disparado imediatamente.
+func (r *Room) RestartTimer() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Status != StateOpen || r.Deadline.IsZero() {
+		return
+	}
+	r.stopTimer()
+
+	remaining := time.Until(r.Deadline)
+	if remaining < 0 {
+		remaining = 0
+	}
+	r.armTimer(r.CurrentQuestionIndex, remaining)
+}
+
+// copyRound faz uma cópia profunda da rodada (inclusive das respostas).
+func copyRound(rd *Round) Round {
+	cp := *rd
+	cp.Answers = make(map[string]*Answer, len(rd.Answers))
+	for id, ans := range rd.Answers {
+		a := *ans
+		cp.Answers[id] = &a
+	}
+	return cp
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"rankit/internal/domain/quiz"
+	"sort"
+	"testing"
+)
+
+// snapshotJSON serializa o snapshot como o repositório faz, com jogadores em ordem estável
+// e todos desconectados (RestoreRoom sempre os restaura assim).
+func snapshotJSON(t *testing.T, s RoomSnapshot) []byte {
+	t.Helper()
+	for _, list := range [][]Player{s.Players, s.PendingPlayers} {
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+		for i := range list {
+			list[i].Connected = false
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return data
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	ordering := quiz.Question{ID: "q-ord", Type: quiz.TipoOrdenacao, Prompt: "Ordene", Items: []string{"1", "2", "3"}, TimeLimit: 30}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, r *Room)
+	}{
+		{
+			name: "lobby com aluno pendente",
+			setup: func(t *testing.T, r *Room) {
+				admitPlayers(t, r, "p1")
+				if _, err := r.JoinRequest("p2", "pendente"); err != nil {
+					t.Fatalf("JoinRequest: %v", err)
+				}
+			},
+		},
+		{
+			name: "pergunta aberta com resposta",
+			setup: func(t *testing.T, r *Room) {
+				admitPlayers(t, r, "p1", "p2")
+				startGame(t, r)
+				if _, err := r.SubmitAnswer("p1", quiz.Response{Indexes: []int{0}}); err != nil {
+					t.Fatalf("SubmitAnswer: %v", err)
+				}
+			},
+		},
+		{
+			name: "segunda pergunta pausada após revelação",
+			setup: func(t *testing.T, r *Room) {
+				admitPlayers(t, r, "p1", "p2")
+				startGame(t, r)
+				answerAndReveal(t, r, map[string]int{"p1": 0, "p2": 1})
+				if err := r.NextQuestion(); err != nil {
+					t.Fatalf("NextQuestion: %v", err)
+				}
+				if _, err := r.SubmitAnswer("p2", quiz.Response{Order: []int{0, 1, 2}}); err != nil {
+					t.Fatalf("SubmitAnswer: %v", err)
+				}
+				if err := r.Pause(); err != nil {
+					t.Fatalf("Pause: %v", err)
+				}
+				if _, err := r.IssueDisplayCode(); err != nil {
+					t.Fatalf("IssueDisplayCode: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := RoomSettings{ShuffleOptions: true, TeamMode: true, AutoBalance: true, TeamNames: []string{"Azul", "Verde"}}
+			r := newTestRoom(t, settings, quiz.PontuacaoVelocidade, timedQuestion(0, 30), ordering)
+			defer r.Close()
+			tt.setup(t, r)
+
+			// Ida e volta pelo JSON, como no SQLite
+			original := r.Snapshot()
+			data, err := json.Marshal(original)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			var decoded RoomSnapshot
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			restored := RestoreRoom(decoded)
+			defer restored.Close()
+
+			want := snapshotJSON(t, original)
+			got := snapshotJSON(t, restored.Snapshot())
+			if !bytes.Equal(got, want) {
+				t.Fatalf("snapshot restaurado difere do original\n got: %s\nwant: %s", got, want)
+			}
+
+			for _, p := range restored.Players {
+				if p.Connected {
+					t.Fatalf("%s restaurado como conectado", p.ID)
+				}
+			}
+			// Answers precisa continuar ligado à rodada da pergunta atual
+			if round := restored.currentRound(); round != nil && round.QuestionIndex == restored.CurrentQuestionIndex {
+				restored.Answers["sentinela"] = &Answer{}
+				if _, ok := round.Answers["sentinela"]; !ok {
+					t.Fatal("Answers restaurado não aponta para a rodada atual")
+				}
+			}
+		})
+	}
+}
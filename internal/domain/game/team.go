@@ -0,0 +1,198 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Limite de times por sala
+const TimesMaximos = 12
+
+var (
+	ErrModoTimesDesativado = errors.New("a sala não está no modo times")
+	ErrTimeNaoEncontrado   = errors.New("time não encontrado na sala")
+	ErrNomesTimesInvalidos = errors.New("os times devem ter nomes não vazios e diferentes entre si (máximo 12)")
+)
+
+// Team é um grupo de alunos que pontua em conjunto.
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TeamStanding é a posição de um time no placar.
+type TeamStanding struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Score       int    `json:"score"` // Soma ou média dos membros, conforme RoomSettings.TeamScoring
+	MemberCount int    `json:"memberCount"`
+}
+
+// DefineTeams substitui os times da sala (apenas no lobby). Os alunos perdem o time
+// anterior e, com auto balanceamento, são redistribuídos nos novos times.
+func (r *Room) DefineTeams(names []string) ([]Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if !r.Settings.TeamMode {
+		return nil, ErrModoTimesDesativado
+	}
+	if r.Status != StateLobby {
+		return nil, ErrSalaIniciada
+	}
+	if err := validateTeamNames(names); err != nil {
+		return nil, err
+	}
+
+	r.Teams = newTeams(names)
+	for _, p := range r.Players {
+		p.TeamID = ""
+	}
+	if r.Settings.AutoBalance {
+		for _, p := range sortedPlayers(r.Players) {
+			r.autoAssignTeam(p)
+		}
+	}
+	return r.copyTeams(), nil
+}
+
+// AssignTeam coloca um aluno aprovado em um time (o professor pode mover alunos a qualquer momento).
+func (r *Room) AssignTeam(playerID, teamID string) (*Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touch()
+
+	if !r.Settings.TeamMode {
+		return nil, ErrModoTimesDesativado
+	}
+	p, ok := r.Players[playerID]
+	if !ok {
+		return nil, ErrJogadorNaoEncontrado
+	}
+	if r.findTeam(teamID) == nil {
+		return nil, ErrTimeNaoEncontrado
+	}
+	p.TeamID = teamID
+	return p, nil
+}
+
+// GetTeams retorna os times da sala.
+func (r *Room) GetTeams() []Team {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.copyTeams()
+}
+
+// GetTeamLeaderboard retorna o placar dos times, do maior para o menor.
+func (r *Room) GetTeamLeaderboard() []TeamStanding {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.teamStandings()
+}
+
+// teamStandings agrega a pontuação dos membros. Deve ser chamado com o lock adquirido.
+func (r *Room) teamStandings() []TeamStanding {
+	standings := make([]TeamStanding, 0, len(r.Teams))
+	index := make(map[string]int, len(r.Teams))
+	for i, t := range r.Teams {
+		standings = append(standings, TeamStanding{ID: t.ID, Name: t.Name})
+		index[t.ID] = i
+	}
+
+	for _, p := range r.Players {
+		if i, ok := index[p.TeamID]; ok {
+			standings[i].Score += p.Score
+			standings[i].MemberCount++
+		}
+	}
+
+	if r.Settings.TeamScoring == PontuacaoTimeMedia {
+		for i := range standings {
+			if standings[i].MemberCount > 0 {
+				standings[i].Score = standings[i].Score / standings[i].MemberCount
+			}
+		}
+	}
+
+	// Ordena por pontuação; empate mantém a ordem de criação dos times
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Score > standings[j].Score
+	})
+	return standings
+}
+
+// autoAssignTeam coloca o aluno no time com menos membros. Deve ser chamado com o lock adquirido.
+func (r *Room) autoAssignTeam(p *Player) {
+	if len(r.Teams) == 0 {
+		return
+	}
+	counts := make(map[string]int, len(r.Teams))
+	for _, other := range r.Players {
+		if other.ID != p.ID {
+			counts[other.TeamID]++
+		}
+	}
+	best := r.Teams[0]
+	for _, t := range r.Teams[1:] {
+		if counts[t.ID] < counts[best.ID] {
+			best = t
+		}
+	}
+	p.TeamID = best.ID
+}
+
+// findTeam busca um time pelo ID. Deve ser chamado com o lock adquirido.
+func (r *Room) findTeam(id string) *Team {
+	for _, t := range r.Teams {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// copyTeams copia a lista de times. Deve ser chamado com o lock adquirido.
+func (r *Room) copyTeams() []Team {
+	teams := make([]Team, 0, len(r.Teams))
+	for _, t := range r.Teams {
+		teams = append(teams, *t)
+	}
+	return teams
+}
+
+func newTeams(names []string) []*Team {
+	teams := make([]*Team, 0, len(names))
+	for _, name := range names {
+		teams = append(teams, &Team{ID: uuid.NewString()[:8], Name: strings.TrimSpace(name)})
+	}
+	return teams
+}
+
+func validateTeamNames(names []string) error {
+	if len(names) > TimesMaximos {
+		return ErrNomesTimesInvalidos
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || seen[key] {
+			return ErrNomesTimesInvalidos
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// sortedPlayers ordena os jogadores por apelido, para uma distribuição estável entre times.
+func sortedPlayers(players map[string]*Player) []*Player {
+	list := make([]*Player, 0, len(players))
+	for _, p := range players {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Nickname < list[j].Nickname })
+	return list
+}
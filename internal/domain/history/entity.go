@@ -14,7 +14,9 @@ type RoomHistory struct {
 	StartedAt         time.Time `json:"startedAt"`
 	FinishedAt        time.Time `json:"finishedAt"`
 	CreatedAt         time.Time `json:"createdAt"`
+	TeamScoring       string    `json:"teamScoring,omitempty"` // SUM ou AVERAGE (vazio fora do modo times)
 
+	Teams     []TeamStats     `json:"teams,omitempty"`
 	Players   []PlayerStats   `json:"players,omitempty"`
 	Questions []QuestionStats `json:"questions,omitempty"`
 	Answers   []PlayerAnswer  `json:"answers,omitempty"`
@@ -28,6 +30,17 @@ type PlayerStats struct {
 	Score           int    `json:"score"`
 	CorrectCount    int    `json:"correctCount"`
 	WrongCount      int    `json:"wrongCount"`
+	TeamID          string `json:"teamId,omitempty"` // TeamStats.TeamRuntimeID do time do aluno
+}
+
+// TeamStats é o resultado final de um time (modo times).
+type TeamStats struct {
+	ID            string `json:"id"`
+	RoomHistoryID string `json:"roomHistoryId"`
+	TeamRuntimeID string `json:"teamRuntimeId"`
+	Name          string `json:"name"`
+	Score         int    `json:"score"`
+	MemberCount   int    `json:"memberCount"`
 }
 
 type QuestionStats struct {
@@ -36,19 +49,22 @@ type QuestionStats struct {
 	QuestionIndex  int    `json:"questionIndex"`
 	QuestionID     string `json:"questionId"`
 	PromptSnapshot string `json:"promptSnapshot"`
-	CorrectIndex   int    `json:"correctIndex"`
-	CountA         int    `json:"countA"`
-	CountB         int    `json:"countB"`
-	CountC         int    `json:"countC"`
-	CountD         int    `json:"countD"`
+	QuestionType   string `json:"questionType"`
+	CorrectIndexes []int  `json:"correctIndexes"`
+	OptionCounts   []int  `json:"optionCounts"` // Quantos alunos marcaram cada alternativa
 	CorrectCount   int    `json:"correctCount"`
+	Skipped        bool   `json:"skipped"` // Pulada pelo professor (não pontuou)
 }
 
 type PlayerAnswer struct {
-	ID            string `json:"id"`
-	RoomHistoryID string `json:"roomHistoryId"`
-	QuestionIndex int    `json:"questionIndex"`
-	RoomPlayerID  string `json:"roomPlayerId"`
-	SelectedIndex int    `json:"selectedIndex"` // -1 se não respondeu?
-	IsCorrect     bool   `json:"isCorrect"`
+	ID              string   `json:"id"`
+	RoomHistoryID   string   `json:"roomHistoryId"`
+	QuestionIndex   int      `json:"questionIndex"`
+	RoomPlayerID    string   `json:"roomPlayerId"`
+	SelectedIndexes []int    `json:"selectedIndexes"`      // Vazio se não respondeu
+	AnswerText      string   `json:"answerText,omitempty"` // Texto digitado (OPEN_TEXT e NUMERIC)
+	Order           []int    `json:"order,omitempty"`      // ORDERING: itens na ordem escolhida
+	Pairs           [][2]int `json:"pairs,omitempty"`      // MATCHING: pares [esquerda, direita]
+	IsCorrect       bool     `json:"isCorrect"`
+	ChangeCount     int      `json:"changeCount"` // Quantas vezes o aluno trocou a resposta
 }
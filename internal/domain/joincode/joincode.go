@@ -0,0 +1,23 @@
+package joincode
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// Faixa do código de entrada: 6 dígitos, sem zero à esquerda (fácil de ditar e digitar).
+const (
+	codigoMinimo = 100000
+	codigoMaximo = 999999
+)
+
+// Generate sorteia o código numérico que os alunos digitam para entrar numa sala ao vivo ou
+// numa tarefa. A unicidade é garantida por quem chama (consulta ao repositório).
+func Generate() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(codigoMaximo-codigoMinimo+1))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", n.Int64()+codigoMinimo), nil
+}
@@ -13,11 +13,18 @@ const (
 	StatusPublicado = "PUBLISHED"
 )
 
+// Modos de pontuação
+const (
+	PontuacaoFixa       = "FLAT"  // Mesma pontuação para toda resposta correta
+	PontuacaoVelocidade = "SPEED" // Pontuação proporcional à rapidez da resposta
+)
+
 var (
 	ErrTituloObrigatorio     = errors.New("o título é obrigatório")
 	ErrQuizPublicadoNaoEdita = errors.New("não é permitido editar um quiz publicado")
 	ErrQuizSemPerguntas      = errors.New("o quiz deve ter pelo menos uma pergunta para ser publicado")
 	ErrPerguntaInvalida      = errors.New("existem perguntas inválidas no quiz")
+	ErrModoPontuacaoInvalido = errors.New("o modo de pontuação deve ser FLAT ou SPEED")
 )
 
 // Quiz representa um conjunto de perguntas criado por um professor.
@@ -29,16 +36,21 @@ type Quiz struct {
 	Subject     string     `json:"subject,omitempty"` // Disciplina (ex: História)
 	Grade       string     `json:"grade,omitempty"`   // Série (ex: 7º Ano)
 	Status      string     `json:"status"`            // DRAFT | PUBLISHED
+	ScoringMode string     `json:"scoringMode"`       // FLAT | SPEED
 	Questions   []Question `json:"questions,omitempty"`
 	CreatedAt   time.Time  `json:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt"`
 }
 
 // NewQuiz cria um novo rascunho de quiz.
-func NewQuiz(teacherID, title, description, subject, grade string) (*Quiz, error) {
+func NewQuiz(teacherID, title, description, subject, grade, scoringMode string) (*Quiz, error) {
 	if title == "" {
 		return nil, ErrTituloObrigatorio
 	}
+	scoringMode, err := normalizeScoringMode(scoringMode)
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	return &Quiz{
@@ -49,6 +61,7 @@ func NewQuiz(teacherID, title, description, subject, grade string) (*Quiz, error
 		Subject:     subject,
 		Grade:       grade,
 		Status:      StatusRascunho,
+		ScoringMode: scoringMode,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Questions:   []Question{},
@@ -81,18 +94,35 @@ func (q *Quiz) Publish() error {
 }
 
 // UpdateMetadata atualiza dados básicos do quiz.
-func (q *Quiz) UpdateMetadata(title, description, subject, grade string) error {
+func (q *Quiz) UpdateMetadata(title, description, subject, grade, scoringMode string) error {
 	if err := q.CanEdit(); err != nil {
 		return err
 	}
 	if title == "" {
 		return ErrTituloObrigatorio
 	}
+	scoringMode, err := normalizeScoringMode(scoringMode)
+	if err != nil {
+		return err
+	}
 
 	q.Title = title
 	q.Description = description
 	q.Subject = subject
 	q.Grade = grade
+	q.ScoringMode = scoringMode
 	q.UpdatedAt = time.Now()
 	return nil
 }
+
+// normalizeScoringMode aplica o padrão FLAT e valida o modo informado.
+func normalizeScoringMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return PontuacaoFixa, nil
+	case PontuacaoFixa, PontuacaoVelocidade:
+		return mode, nil
+	default:
+		return "", ErrModoPontuacaoInvalido
+	}
+}
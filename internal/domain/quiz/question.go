@@ -2,49 +2,126 @@ package quiz
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 var (
 	ErrEnunciadoObrigatorio = errors.New("o enunciado (prompt) é obrigatório")
-	ErrAlternativaVazia     = errors.New("todas as 4 alternativas devem ser preenchidas")
-	ErrIndiceInvalido       = errors.New("o índice da resposta correta deve ser entre 0 e 3")
+	ErrTipoPerguntaInvalido = errors.New("tipo de pergunta inválido (use MULTIPLE_CHOICE, TRUE_FALSE, MULTI_SELECT, OPEN_TEXT, NUMERIC, POLL, ORDERING ou MATCHING)")
+	ErrQuantidadeOpcoes     = errors.New("a pergunta deve ter entre 2 e 6 alternativas")
+	ErrVerdadeiroFalso      = errors.New("perguntas de verdadeiro ou falso devem ter exatamente 2 alternativas")
+	ErrAlternativaVazia     = errors.New("todas as alternativas devem ser preenchidas")
+	ErrIndiceInvalido       = errors.New("os índices das respostas corretas devem apontar para alternativas existentes")
+	ErrRespostaCorretaUnica = errors.New("este tipo de pergunta deve ter exatamente uma resposta correta")
+	ErrRespostasAceitas     = errors.New("perguntas de resposta aberta precisam de ao menos uma resposta aceita")
+	ErrRespostaNumerica     = errors.New("perguntas numéricas precisam da resposta correta (numericAnswer)")
+	ErrToleranciaInvalida   = errors.New("a tolerância não pode ser negativa")
+	ErrQuantidadeItens      = errors.New("a pergunta deve ter entre 2 e 8 itens")
+	ErrItemVazio            = errors.New("todos os itens devem ser preenchidos")
+	ErrColunasAssociacao    = errors.New("as duas colunas da associação devem ter o mesmo número de itens")
+	ErrTempoLimiteInvalido  = errors.New("o tempo limite deve ser 0 (sem limite) ou entre 5 e 600 segundos")
+	ErrRespostaInvalida     = errors.New("resposta inválida para esta pergunta")
+	ErrMultiplicadorPontos  = errors.New("o multiplicador de pontos deve ser entre 1 e 3")
 )
 
-// Question representa uma pergunta de múltipla escolha.
+// Tipos de pergunta
+const (
+	TipoMultiplaEscolha = "MULTIPLE_CHOICE" // Uma alternativa correta
+	TipoVerdadeiroFalso = "TRUE_FALSE"      // Duas alternativas, uma correta
+	TipoMultiplaSelecao = "MULTI_SELECT"    // Uma ou mais alternativas corretas (crédito parcial)
+	TipoTextoAberto     = "OPEN_TEXT"       // Aluno digita a resposta, comparada com as respostas aceitas
+	TipoNumerico        = "NUMERIC"         // Aluno digita um número, aceito dentro da tolerância
+	TipoEnquete         = "POLL"            // Enquete de opinião: sem resposta correta e sem pontuação
+	TipoOrdenacao       = "ORDERING"        // Colocar os itens na ordem correta (crédito parcial)
+	TipoAssociacao      = "MATCHING"        // Associar cada item da esquerda ao da direita (crédito parcial)
+)
+
+// Limites do tempo de resposta por pergunta (em segundos)
+const (
+	TempoLimiteMinimo = 5
+	TempoLimiteMaximo = 600
+)
+
+// Limites do multiplicador de pontos por pergunta (2 = "pontos em dobro")
+const (
+	MultiplicadorMinimo = 1
+	MultiplicadorMaximo = 3
+)
+
+// Limites de alternativas por pergunta
+const (
+	OpcoesMinimas = 2
+	OpcoesMaximas = 6
+)
+
+// Limites de itens em perguntas de ordenação e associação
+const (
+	ItensMinimos = 2
+	ItensMaximos = 8
+)
+
+// Alternativas usadas quando uma pergunta TRUE_FALSE é criada sem texto próprio
+var opcoesVerdadeiroFalso = []string{"Verdadeiro", "Falso"}
+
+// Question representa uma pergunta do quiz.
 type Question struct {
-	ID           string    `json:"id"`
-	QuizID       string    `json:"quizId"`
-	Prompt       string    `json:"prompt"`       // Enunciado
-	OptionA      string    `json:"optionA"`      // 0
-	OptionB      string    `json:"optionB"`      // 1
-	OptionC      string    `json:"optionC"`      // 2
-	OptionD      string    `json:"optionD"`      // 3
-	CorrectIndex int       `json:"correctIndex"` // 0..3
-	SortOrder    int       `json:"sortOrder"`    // Ordem na lista
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID             string   `json:"id"`
+	QuizID         string   `json:"quizId"`
+	Type           string   `json:"type"`             // Ver constantes Tipo*
+	Prompt         string   `json:"prompt"`           // Enunciado
+	Options        []string `json:"options"`          // Alternativas, na ordem exibida
+	CorrectIndexes []int    `json:"correctIndexes"`   // Índices das alternativas corretas
+	TimeLimit      int      `json:"timeLimitSeconds"` // Segundos para responder (0 = sem limite)
+
+	PointsMultiplier int `json:"pointsMultiplier"` // Multiplica os pontos da pergunta (1 = normal, 2 = em dobro)
+
+	AcceptedAnswers []string `json:"acceptedAnswers,omitempty"` // OPEN_TEXT: respostas aceitas (sem diferenciar maiúsculas, acentos e espaços)
+	NumericAnswer   *float64 `json:"numericAnswer,omitempty"`   // NUMERIC: valor correto
+	Tolerance       float64  `json:"tolerance,omitempty"`       // NUMERIC: diferença máxima aceita (0 = valor exato)
+
+	Items      []string `json:"items,omitempty"`      // ORDERING: itens na ordem correta
+	MatchLeft  []string `json:"matchLeft,omitempty"`  // MATCHING: coluna da esquerda
+	MatchRight []string `json:"matchRight,omitempty"` // MATCHING: coluna da direita (MatchRight[i] corresponde a MatchLeft[i])
+
+	SortOrder int       `json:"sortOrder"` // Ordem na lista
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// QuestionData agrupa os campos editáveis de uma pergunta.
+type QuestionData struct {
+	Type           string
+	Prompt         string
+	Options        []string
+	CorrectIndexes []int
+	TimeLimit      int
+
+	PointsMultiplier int // 0 = padrão (1)
+
+	AcceptedAnswers []string
+	NumericAnswer   *float64
+	Tolerance       float64
+
+	Items      []string
+	MatchLeft  []string
+	MatchRight []string
 }
 
 // NewQuestion cria uma nova pergunta.
-func NewQuestion(quizID, prompt, optA, optB, optC, optD string, correctIndex, order int) (*Question, error) {
+func NewQuestion(quizID string, data QuestionData, order int) (*Question, error) {
 	q := &Question{
-		ID:           uuid.NewString(),
-		QuizID:       quizID,
-		Prompt:       prompt,
-		OptionA:      optA,
-		OptionB:      optB,
-		OptionC:      optC,
-		OptionD:      optD,
-		CorrectIndex: correctIndex,
-		SortOrder:    order,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-	}
-
-	if err := q.Validate(); err != nil {
+		ID:        uuid.NewString(),
+		QuizID:    quizID,
+		SortOrder: order,
+		CreatedAt: time.Now(),
+	}
+
+	if err := q.Update(data); err != nil {
 		return nil, err
 	}
 
@@ -56,24 +133,377 @@ func (q *Question) Validate() error {
 	if q.Prompt == "" {
 		return ErrEnunciadoObrigatorio
 	}
-	if q.OptionA == "" || q.OptionB == "" || q.OptionC == "" || q.OptionD == "" {
-		return ErrAlternativaVazia
+
+	var err error
+	switch q.Type {
+	case TipoMultiplaEscolha, TipoVerdadeiroFalso, TipoMultiplaSelecao, TipoEnquete:
+		err = q.validateOptions()
+	case TipoTextoAberto:
+		err = q.validateOpenText()
+	case TipoNumerico:
+		err = q.validateNumeric()
+	case TipoOrdenacao:
+		err = validateItems(q.Items)
+	case TipoAssociacao:
+		err = q.validateMatching()
+	default:
+		err = ErrTipoPerguntaInvalido
+	}
+	if err != nil {
+		return err
 	}
-	if q.CorrectIndex < 0 || q.CorrectIndex > 3 {
+
+	if q.TimeLimit != 0 && (q.TimeLimit < TempoLimiteMinimo || q.TimeLimit > TempoLimiteMaximo) {
+		return ErrTempoLimiteInvalido
+	}
+	if q.PointsMultiplier < MultiplicadorMinimo || q.PointsMultiplier > MultiplicadorMaximo {
+		return ErrMultiplicadorPontos
+	}
+	return nil
+}
+
+// validateOptions valida perguntas de alternativas (MULTIPLE_CHOICE, TRUE_FALSE, MULTI_SELECT e POLL).
+func (q *Question) validateOptions() error {
+	if q.Type == TipoVerdadeiroFalso {
+		if len(q.Options) != 2 {
+			return ErrVerdadeiroFalso
+		}
+	} else if len(q.Options) < OpcoesMinimas || len(q.Options) > OpcoesMaximas {
+		return ErrQuantidadeOpcoes
+	}
+
+	for _, opt := range q.Options {
+		if opt == "" {
+			return ErrAlternativaVazia
+		}
+	}
+
+	if q.Type == TipoEnquete {
+		return nil // Sem gabarito
+	}
+
+	if !validIndexes(q.CorrectIndexes, len(q.Options)) {
 		return ErrIndiceInvalido
 	}
+	if q.Type == TipoMultiplaSelecao {
+		if len(q.CorrectIndexes) == 0 {
+			return ErrIndiceInvalido
+		}
+	} else if len(q.CorrectIndexes) != 1 {
+		return ErrRespostaCorretaUnica
+	}
 	return nil
 }
 
+// validateOpenText exige ao menos uma resposta aceita que não fique vazia após normalizar.
+func (q *Question) validateOpenText() error {
+	for _, accepted := range q.AcceptedAnswers {
+		if NormalizeText(accepted) != "" {
+			return nil
+		}
+	}
+	return ErrRespostasAceitas
+}
+
+// validateNumeric exige o valor correto e uma tolerância não negativa.
+func (q *Question) validateNumeric() error {
+	if q.NumericAnswer == nil || math.IsNaN(*q.NumericAnswer) || math.IsInf(*q.NumericAnswer, 0) {
+		return ErrRespostaNumerica
+	}
+	if q.Tolerance < 0 || math.IsNaN(q.Tolerance) {
+		return ErrToleranciaInvalida
+	}
+	return nil
+}
+
+// validateMatching exige duas colunas válidas e do mesmo tamanho.
+func (q *Question) validateMatching() error {
+	if err := validateItems(q.MatchLeft); err != nil {
+		return err
+	}
+	if err := validateItems(q.MatchRight); err != nil {
+		return err
+	}
+	if len(q.MatchLeft) != len(q.MatchRight) {
+		return ErrColunasAssociacao
+	}
+	return nil
+}
+
+// validateItems valida uma lista de itens de ordenação ou associação.
+func validateItems(items []string) error {
+	if len(items) < ItensMinimos || len(items) > ItensMaximos {
+		return ErrQuantidadeItens
+	}
+	for _, item := range items {
+		if item == "" {
+			return ErrItemVazio
+		}
+	}
+	return nil
+}
+
+// HasOptions indica se a pergunta é respondida escolhendo alternativas.
+func (q *Question) HasOptions() bool {
+	switch q.Type {
+	case TipoMultiplaEscolha, TipoVerdadeiroFalso, TipoMultiplaSelecao, TipoEnquete:
+		return true
+	}
+	return false
+}
+
+// ShuffleSize retorna quantos itens são embaralhados ao exibir a pergunta
+// (itens da ordenação ou coluna da direita da associação). 0 para os demais tipos.
+func (q *Question) ShuffleSize() int {
+	switch q.Type {
+	case TipoOrdenacao:
+		return len(q.Items)
+	case TipoAssociacao:
+		return len(q.MatchRight)
+	}
+	return 0
+}
+
+// IsScored indica se a pergunta vale pontos (enquetes não têm resposta correta).
+func (q *Question) IsScored() bool {
+	return q.Type != TipoEnquete
+}
+
 // Update atualiza os dados da pergunta.
-func (q *Question) Update(prompt, optA, optB, optC, optD string, correctIndex int) error {
-	q.Prompt = prompt
-	q.OptionA = optA
-	q.OptionB = optB
-	q.OptionC = optC
-	q.OptionD = optD
-	q.CorrectIndex = correctIndex
+func (q *Question) Update(data QuestionData) error {
+	q.Type = data.Type
+	if q.Type == "" {
+		q.Type = TipoMultiplaEscolha
+	}
+	q.Prompt = data.Prompt
+	q.Options = data.Options
+	if q.Type == TipoVerdadeiroFalso && len(q.Options) == 0 {
+		q.Options = append([]string(nil), opcoesVerdadeiroFalso...)
+	}
+	q.CorrectIndexes = data.CorrectIndexes
+	q.TimeLimit = data.TimeLimit
+	q.PointsMultiplier = data.PointsMultiplier
+	if q.PointsMultiplier == 0 || q.Type == TipoEnquete {
+		// Enquetes não pontuam, então o multiplicador não se aplica
+		q.PointsMultiplier = MultiplicadorMinimo
+	}
+	q.AcceptedAnswers = data.AcceptedAnswers
+	q.NumericAnswer = data.NumericAnswer
+	q.Tolerance = data.Tolerance
+	q.Items = data.Items
+	q.MatchLeft = data.MatchLeft
+	q.MatchRight = data.MatchRight
 	q.UpdatedAt = time.Now()
 
+	// Cada tipo guarda apenas o próprio gabarito
+	if !q.HasOptions() {
+		q.Options, q.CorrectIndexes = []string{}, []int{}
+	} else if q.Type == TipoEnquete {
+		q.CorrectIndexes = []int{}
+	}
+	if q.Type != TipoTextoAberto {
+		q.AcceptedAnswers = nil
+	}
+	if q.Type != TipoNumerico {
+		q.NumericAnswer, q.Tolerance = nil, 0
+	}
+	if q.Type != TipoOrdenacao {
+		q.Items = nil
+	}
+	if q.Type != TipoAssociacao {
+		q.MatchLeft, q.MatchRight = nil, nil
+	}
+
 	return q.Validate()
 }
+
+// TimeLimitDuration retorna o tempo limite como time.Duration (0 = sem limite).
+func (q *Question) TimeLimitDuration() time.Duration {
+	return time.Duration(q.TimeLimit) * time.Second
+}
+
+// PublicView retorna uma cópia da pergunta sem o gabarito (enviada aos alunos enquanto aberta).
+// `shuffle` é a ordem de exibição dos itens de ORDERING/MATCHING (ver ShuffleSize): sem ela,
+// a própria lista de itens entregaria a resposta.
+func (q Question) PublicView(shuffle []int) Question {
+	q.CorrectIndexes = nil
+	q.AcceptedAnswers = nil
+	q.NumericAnswer = nil
+	q.Tolerance = 0
+	switch q.Type {
+	case TipoOrdenacao:
+		q.Items = permute(q.Items, shuffle)
+	case TipoAssociacao:
+		q.MatchRight = permute(q.MatchRight, shuffle)
+	}
+	return q
+}
+
+// ToCanonical converte uma resposta dada sobre os itens embaralhados (posições exibidas)
+// para os índices originais da pergunta. Deve ser chamada após CheckResponse.
+func (q *Question) ToCanonical(resp Response, shuffle []int) Response {
+	if len(shuffle) == 0 {
+		return resp
+	}
+	switch q.Type {
+	case TipoOrdenacao:
+		order := make([]int, len(resp.Order))
+		for i, shown := range resp.Order {
+			order[i] = shuffle[shown]
+		}
+		resp.Order = order
+	case TipoAssociacao:
+		pairs := make([][2]int, len(resp.Pairs))
+		for i, p := range resp.Pairs {
+			pairs[i] = [2]int{p[0], shuffle[p[1]]}
+		}
+		resp.Pairs = pairs
+	}
+	return resp
+}
+
+// IsCorrectIndex indica se a alternativa `index` é uma das corretas.
+func (q *Question) IsCorrectIndex(index int) bool {
+	for _, c := range q.CorrectIndexes {
+		if c == index {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckResponse verifica se a resposta é compatível com a pergunta (sem avaliar se está certa).
+func (q *Question) CheckResponse(resp Response) error {
+	switch q.Type {
+	case TipoTextoAberto:
+		text := strings.TrimSpace(resp.Text)
+		if text == "" || utf8.RuneCountInString(text) > TamanhoMaximoResposta {
+			return ErrRespostaInvalida
+		}
+	case TipoNumerico:
+		if _, ok := ParseNumber(resp.Text); !ok {
+			return ErrRespostaInvalida
+		}
+	case TipoOrdenacao:
+		if len(resp.Order) != len(q.Items) || !validIndexes(resp.Order, len(q.Items)) {
+			return ErrRespostaInvalida
+		}
+	case TipoAssociacao:
+		if !validPairs(resp.Pairs, len(q.MatchLeft)) {
+			return ErrRespostaInvalida
+		}
+	default:
+		if !validIndexes(resp.Indexes, len(q.Options)) || len(resp.Indexes) == 0 {
+			return ErrRespostaInvalida
+		}
+		if q.Type != TipoMultiplaSelecao && len(resp.Indexes) != 1 {
+			return ErrRespostaInvalida
+		}
+	}
+	return nil
+}
+
+// Grade avalia a resposta e retorna o crédito obtido, de 0 (errada) a 1 (totalmente correta).
+// Em MULTI_SELECT cada alternativa correta marcada soma e cada incorreta desconta, proporcionalmente.
+func (q *Question) Grade(resp Response) float64 {
+	switch q.Type {
+	case TipoEnquete:
+		return 0 // Enquete não tem resposta certa
+
+	case TipoTextoAberto:
+		text := NormalizeText(resp.Text)
+		for _, accepted := range q.AcceptedAnswers {
+			if text != "" && text == NormalizeText(accepted) {
+				return 1
+			}
+		}
+		return 0
+
+	case TipoNumerico:
+		value, ok := ParseNumber(resp.Text)
+		if ok && q.NumericAnswer != nil && math.Abs(value-*q.NumericAnswer) <= q.Tolerance+toleranciaArredondamento {
+			return 1
+		}
+		return 0
+
+	case TipoOrdenacao:
+		// Crédito proporcional aos itens na posição certa
+		hits := 0
+		for pos, item := range resp.Order {
+			if item == pos {
+				hits++
+			}
+		}
+		return float64(hits) / float64(len(q.Items))
+
+	case TipoAssociacao:
+		// Crédito proporcional aos pares corretos
+		hits := 0
+		for _, p := range resp.Pairs {
+			if p[0] == p[1] {
+				hits++
+			}
+		}
+		return float64(hits) / float64(len(q.MatchLeft))
+
+	case TipoMultiplaSelecao:
+		hits, misses := 0, 0
+		for _, idx := range resp.Indexes {
+			if q.IsCorrectIndex(idx) {
+				hits++
+			} else {
+				misses++
+			}
+		}
+		credit := float64(hits-misses) / float64(len(q.CorrectIndexes))
+		if credit < 0 {
+			return 0
+		}
+		return credit
+
+	default:
+		if len(resp.Indexes) == 1 && q.IsCorrectIndex(resp.Indexes[0]) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// validPairs verifica pares [esquerda, direita] de uma associação com n itens por coluna:
+// ao menos um par e nenhum item usado duas vezes na mesma coluna.
+func validPairs(pairs [][2]int, n int) bool {
+	if len(pairs) == 0 || len(pairs) > n {
+		return false
+	}
+	left := make([]int, 0, len(pairs))
+	right := make([]int, 0, len(pairs))
+	for _, p := range pairs {
+		left = append(left, p[0])
+		right = append(right, p[1])
+	}
+	return validIndexes(left, n) && validIndexes(right, n)
+}
+
+// permute reordena `items` conforme `order` (order[i] = índice original do i-ésimo exibido).
+func permute(items []string, order []int) []string {
+	if len(order) != len(items) {
+		return items
+	}
+	out := make([]string, len(items))
+	for i, idx := range order {
+		out[i] = items[idx]
+	}
+	return out
+}
+
+// validIndexes verifica se todos os índices existem em uma lista de tamanho n, sem repetição.
+func validIndexes(indexes []int, n int) bool {
+	seen := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		if idx < 0 || idx >= n || seen[idx] {
+			return false
+		}
+		seen[idx] = true
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package quiz
+
+import (
+	"math"
+	"testing"
+)
+
+// assertCredit compara o crédito com tolerância de ponto flutuante.
+func assertCredit(t *testing.T, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("crédito = %v, esperado %v", got, want)
+	}
+}
+
+func TestGradeOptions(t *testing.T) {
+	choice := Question{Type: TipoMultiplaEscolha, Options: []string{"A", "B", "C", "D"}, CorrectIndexes: []int{2}}
+	trueFalse := Question{Type: TipoVerdadeiroFalso, Options: []string{"Verdadeiro", "Falso"}, CorrectIndexes: []int{1}}
+	multi := Question{Type: TipoMultiplaSelecao, Options: []string{"A", "B", "C", "D"}, CorrectIndexes: []int{0, 2}}
+	poll := Question{Type: TipoEnquete, Options: []string{"A", "B"}}
+
+	tests := []struct {
+		name     string
+		question Question
+		indexes  []int
+		want     float64
+	}{
+		{name: "múltipla escolha correta", question: choice, indexes: []int{2}, want: 1},
+		{name: "múltipla escolha errada", question: choice, indexes: []int{0}, want: 0},
+		{name: "múltipla escolha com duas marcadas", question: choice, indexes: []int{2, 0}, want: 0},
+		{name: "verdadeiro ou falso correta", question: trueFalse, indexes: []int{1}, want: 1},
+		{name: "verdadeiro ou falso errada", question: trueFalse, indexes: []int{0}, want: 0},
+		{name: "seleção completa", question: multi, indexes: []int{0, 2}, want: 1},
+		{name: "seleção parcial", question: multi, indexes: []int{2}, want: 0.5},
+		{name: "acerto e erro se anulam", question: multi, indexes: []int{0, 1}, want: 0},
+		{name: "crédito nunca é negativo", question: multi, indexes: []int{1, 3}, want: 0},
+		{name: "todas marcadas", question: multi, indexes: []int{0, 1, 2, 3}, want: 0},
+		{name: "enquete não pontua", question: poll, indexes: []int{0}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCredit(t, tt.question.Grade(Response{Indexes: tt.indexes}), tt.want)
+		})
+	}
+}
+
+func TestCheckResponseOptions(t *testing.T) {
+	choice := Question{Type: TipoMultiplaEscolha, Options: []string{"A", "B", "C"}}
+	multi := Question{Type: TipoMultiplaSelecao, Options: []string{"A", "B", "C"}}
+
+	tests := []struct {
+		name     string
+		question Question
+		resp     Response
+		valid    bool
+	}{
+		{name: "uma alternativa", question: choice, resp: Response{Indexes: []int{1}}, valid: true},
+		{name: "sem alternativa", question: choice, resp: Response{}, valid: false},
+		{name: "duas alternativas em múltipla escolha", question: choice, resp: Response{Indexes: []int{0, 1}}, valid: false},
+		{name: "índice fora das alternativas", question: choice, resp: Response{Indexes: []int{3}}, valid: false},
+		{name: "várias alternativas em seleção múltipla", question: multi, resp: Response{Indexes: []int{0, 2}}, valid: true},
+		{name: "alternativa repetida", question: multi, resp: Response{Indexes: []int{1, 1}}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.question.CheckResponse(tt.resp)
+			if (err == nil) != tt.valid {
+				t.Fatalf("CheckResponse(%+v) = %v, válida esperada: %v", tt.resp, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestGradeTextAndNumeric(t *testing.T) {
+	text := Question{Type: TipoTextoAberto, AcceptedAnswers: []string{"São Paulo", "SP"}}
+	numeric := Question{Type: TipoNumerico, NumericAnswer: ptr(3.14), Tolerance: 0.01}
+	exact := Question{Type: TipoNumerico, NumericAnswer: ptr(0)}
+
+	tests := []struct {
+		name     string
+		question Question
+		text     string
+		want     float64
+	}{
+		{name: "texto igual", question: text, text: "São Paulo", want: 1},
+		{name: "texto sem acento e com espaços", question: text, text: "  sao   PAULO ", want: 1},
+		{name: "segunda resposta aceita", question: text, text: "sp", want: 1},
+		{name: "texto diferente", question: text, text: "Santos", want: 0},
+		{name: "texto vazio", question: text, text: "   ", want: 0},
+		{name: "número exato", question: numeric, text: "3.14", want: 1},
+		{name: "número com vírgula dentro da tolerância", question: numeric, text: "3,15", want: 1},
+		{name: "número fora da tolerância", question: numeric, text: "3.16", want: 0},
+		{name: "número inválido", question: numeric, text: "pi", want: 0},
+		{name: "arredondamento de ponto flutuante", question: Question{Type: TipoNumerico, NumericAnswer: ptr(0.3)}, text: "0.30000000000000004", want: 1},
+		{name: "sem tolerância exige o valor exato", question: exact, text: "0.001", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCredit(t, tt.question.Grade(Response{Text: tt.text}), tt.want)
+		})
+	}
+}
+
+// ptr devolve o endereço de um valor numérico (campo NumericAnswer).
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestGradeOrderingAndMatching(t *testing.T) {
+	ordering := Question{Type: TipoOrdenacao, Items: []string{"1", "2", "3", "4"}}
+	matching := Question{Type: TipoAssociacao, MatchLeft: []string{"a", "b", "c", "d"}, MatchRight: []string{"A", "B", "C", "D"}}
+
+	tests := []struct {
+		name     string
+		question Question
+		resp     Response
+		want     float64
+	}{
+		{name: "ordem correta", question: ordering, resp: Response{Order: []int{0, 1, 2, 3}}, want: 1},
+		{name: "dois itens no lugar", question: ordering, resp: Response{Order: []int{0, 1, 3, 2}}, want: 0.5},
+		{name: "ordem invertida", question: ordering, resp: Response{Order: []int{3, 2, 1, 0}}, want: 0},
+		{name: "associação completa", question: matching, resp: Response{Pairs: [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}}}, want: 1},
+		{name: "associação parcial", question: matching, resp: Response{Pairs: [][2]int{{0, 0}, {1, 2}, {2, 1}, {3, 3}}}, want: 0.5},
+		{name: "pares faltando contam como erro", question: matching, resp: Response{Pairs: [][2]int{{0, 0}}}, want: 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCredit(t, tt.question.Grade(tt.resp), tt.want)
+		})
+	}
+}
+
+func TestShuffledItemsRoundTrip(t *testing.T) {
+	ordering := Question{Type: TipoOrdenacao, Items: []string{"primeiro", "segundo", "terceiro"}}
+	matching := Question{Type: TipoAssociacao, MatchLeft: []string{"a", "b", "c"}, MatchRight: []string{"A", "B", "C"}}
+	shuffle := []int{2, 0, 1} // Posição exibida -> índice original
+
+	// O aluno vê os itens embaralhados e responde com as posições exibidas
+	shown := ordering.PublicView(shuffle)
+	if got := shown.Items; got[0] != "terceiro" || got[1] != "primeiro" || got[2] != "segundo" {
+		t.Fatalf("itens exibidos = %v", got)
+	}
+	resp := ordering.ToCanonical(Response{Order: []int{1, 2, 0}}, shuffle)
+	assertCredit(t, ordering.Grade(resp), 1)
+
+	shownMatch := matching.PublicView(shuffle)
+	if got := shownMatch.MatchRight; got[0] != "C" || got[1] != "A" || got[2] != "B" {
+		t.Fatalf("coluna da direita exibida = %v", got)
+	}
+	respMatch := matching.ToCanonical(Response{Pairs: [][2]int{{0, 1}, {1, 2}, {2, 0}}}, shuffle)
+	assertCredit(t, matching.Grade(respMatch), 1)
+
+	if ordering.Items[0] != "primeiro" {
+		t.Fatal("PublicView não pode alterar a pergunta original")
+	}
+}
+
+func TestCheckResponseItems(t *testing.T) {
+	ordering := Question{Type: TipoOrdenacao, Items: []string{"1", "2", "3"}}
+	matching := Question{Type: TipoAssociacao, MatchLeft: []string{"a", "b"}, MatchRight: []string{"A", "B"}}
+
+	tests := []struct {
+		name     string
+		question Question
+		resp     Response
+		valid    bool
+	}{
+		{name: "ordem completa", question: ordering, resp: Response{Order: []int{2, 0, 1}}, valid: true},
+		{name: "ordem incompleta", question: ordering, resp: Response{Order: []int{0, 1}}, valid: false},
+		{name: "item repetido", question: ordering, resp: Response{Order: []int{0, 0, 1}}, valid: false},
+		{name: "pares válidos", question: matching, resp: Response{Pairs: [][2]int{{0, 1}, {1, 0}}}, valid: true},
+		{name: "sem pares", question: matching, resp: Response{}, valid: false},
+		{name: "item da direita repetido", question: matching, resp: Response{Pairs: [][2]int{{0, 1}, {1, 1}}}, valid: false},
+		{name: "item inexistente", question: matching, resp: Response{Pairs: [][2]int{{0, 2}}}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.question.CheckResponse(tt.resp)
+			if (err == nil) != tt.valid {
+				t.Fatalf("CheckResponse(%+v) = %v, válida esperada: %v", tt.resp, err, tt.valid)
+			}
+		})
+	}
+}
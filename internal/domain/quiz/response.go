@@ -0,0 +1,93 @@
+package quiz
+
+import (
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TamanhoMaximoResposta limita o texto digitado pelo aluno (em caracteres).
+const TamanhoMaximoResposta = 200
+
+// toleranciaArredondamento absorve erros de ponto flutuante na comparação numérica (ex: 0.1 + 0.2).
+const toleranciaArredondamento = 1e-9
+
+// milharAmbiguo casa números como "1.000" ou "12,500": o separador pode ser de milhar ou decimal.
+var milharAmbiguo = regexp.MustCompile(`^[+-]?[1-9][0-9]{0,2}[.,][0-9]{3}$`)
+
+// Response é a resposta enviada por um aluno.
+type Response struct {
+	Indexes []int    `json:"indexes,omitempty"` // Alternativas marcadas
+	Text    string   `json:"text,omitempty"`    // Texto digitado (OPEN_TEXT e NUMERIC)
+	Order   []int    `json:"order,omitempty"`   // ORDERING: índices dos itens na ordem escolhida
+	Pairs   [][2]int `json:"pairs,omitempty"`   // MATCHING: pares [esquerda, direita]
+}
+
+// acentos mapeia letras acentuadas para a forma sem acento.
+var acentos = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n',
+}
+
+// NormalizeText prepara um texto para comparação: minúsculas, sem acentos e
+// com espaços repetidos (ou nas pontas) removidos. Ex: "  São   Paulo " -> "sao paulo".
+func NormalizeText(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(s) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		for _, r := range word {
+			r = unicode.ToLower(r)
+			if plain, ok := acentos[r]; ok {
+				r = plain
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseNumber interpreta o número digitado pelo aluno. Aceita vírgula como separador
+// decimal ("3,5") e ignora espaços nas pontas. Recusa números agrupados por milhar
+// ("1.000", "1,000"), que não dá para saber se valem mil ou um.
+func ParseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if milharAmbiguo.MatchString(s) {
+		return 0, false
+	}
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+// NewShuffle sorteia a ordem de exibição de n itens. Para n >= 2 nunca devolve a ordem
+// original, que entregaria a resposta de uma pergunta de ordenação.
+func NewShuffle(n int) []int {
+	perm := rand.Perm(n)
+	for n >= 2 && isIdentity(perm) {
+		perm = rand.Perm(n)
+	}
+	return perm
+}
+
+func isIdentity(perm []int) bool {
+	for i, v := range perm {
+		if i != v {
+			return false
+		}
+	}
+	return true
+}
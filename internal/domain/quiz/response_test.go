@@ -0,0 +1,55 @@
+package quiz
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "  São   Paulo ", want: "sao paulo"},
+		{in: "AÇÃO", want: "acao"},
+		{in: "Pão\tde\nQueijo", want: "pao de queijo"},
+		{in: "Über Ñandú", want: "uber nandu"},
+		{in: "   ", want: ""},
+		{in: "42", want: "42"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeText(tt.in); got != tt.want {
+			t.Fatalf("NormalizeText(%q) = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{in: "3.5", want: 3.5, ok: true},
+		{in: "3,5", want: 3.5, ok: true},
+		{in: "  -12 ", want: -12, ok: true},
+		{in: "1e3", want: 1000, ok: true},
+		{in: "1.000,5", ok: false}, // Separador de milhar não é aceito
+		{in: "1.000", ok: false},   // Mil ou um: ambíguo
+		{in: "1,000", ok: false},
+		{in: "-12.500", ok: false},
+		{in: "0,125", want: 0.125, ok: true}, // Milhar não começa com zero
+		{in: "1.5", want: 1.5, ok: true},
+		{in: "1,2345", want: 1.2345, ok: true},
+		{in: "1000.000", want: 1000, ok: true},
+		{in: "abc", ok: false},
+		{in: "", ok: false},
+		{in: "NaN", ok: false},
+		{in: "Inf", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseNumber(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Fatalf("ParseNumber(%q) = (%v, %v), esperado (%v, %v)", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
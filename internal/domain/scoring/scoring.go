@@ -0,0 +1,76 @@
+package scoring
+
+import (
+	"math"
+	"rankit/internal/domain/quiz"
+	"time"
+)
+
+const (
+	// PontosFixos é o valor de uma resposta correta no modo FLAT.
+	PontosFixos = 10
+
+	// PontosMaximos e PontosMinimos delimitam a pontuação no modo SPEED.
+	PontosMaximos = 1000
+	PontosMinimos = 500
+
+	// DefaultAnswerWindow é a janela usada para medir velocidade quando a pergunta não tem tempo limite.
+	DefaultAnswerWindow = 30 * time.Second
+
+	// BonusSequencia é o acréscimo por acerto consecutivo a partir do segundo (10%),
+	// limitado a MultiplicadorSequenciaMaximo.
+	BonusSequencia               = 0.1
+	MultiplicadorSequenciaMaximo = 1.5
+)
+
+// StreakMultiplier retorna o multiplicador de uma resposta correta que estende a
+// sequência de acertos para `streak` (1 = primeiro acerto, sem bônus).
+func StreakMultiplier(streak int) float64 {
+	if streak <= 1 {
+		return 1
+	}
+	return math.Min(1+BonusSequencia*float64(streak-1), MultiplicadorSequenciaMaximo)
+}
+
+// Scorer define a fórmula de pontuação de uma resposta correta.
+type Scorer interface {
+	// Points calcula os pontos de uma resposta correta enviada após `elapsed`,
+	// considerando a janela total de resposta `window`.
+	Points(elapsed, window time.Duration) int
+}
+
+// FlatScorer dá a mesma pontuação para qualquer resposta correta.
+type FlatScorer struct {
+	Value int
+}
+
+func (s FlatScorer) Points(elapsed, window time.Duration) int {
+	return s.Value
+}
+
+// SpeedScorer decai linearmente de Max (resposta imediata) até Min (fim da janela).
+type SpeedScorer struct {
+	Max int
+	Min int
+}
+
+func (s SpeedScorer) Points(elapsed, window time.Duration) int {
+	if window <= 0 || elapsed <= 0 {
+		return s.Max
+	}
+	if elapsed >= window {
+		return s.Min
+	}
+	ratio := float64(elapsed) / float64(window)
+	return s.Max - int(float64(s.Max-s.Min)*ratio)
+}
+
+// NewScorer retorna a fórmula correspondente ao modo de pontuação do quiz.
+func NewScorer(mode string) Scorer {
+	switch mode {
+	case quiz.PontuacaoVelocidade:
+		return SpeedScorer{Max: PontosMaximos, Min: PontosMinimos}
+	default:
+		return FlatScorer{Value: PontosFixos}
+	}
+}
@@ -0,0 +1,80 @@
+package scoring
+
+import (
+	"rankit/internal/domain/quiz"
+	"testing"
+	"time"
+)
+
+func TestFlatScorer(t *testing.T) {
+	s := FlatScorer{Value: PontosFixos}
+	for _, elapsed := range []time.Duration{0, 5 * time.Second, time.Minute} {
+		if got := s.Points(elapsed, 30*time.Second); got != PontosFixos {
+			t.Fatalf("Points(%v) = %d, esperado %d", elapsed, got, PontosFixos)
+		}
+	}
+}
+
+func TestSpeedScorer(t *testing.T) {
+	s := SpeedScorer{Max: PontosMaximos, Min: PontosMinimos}
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		window  time.Duration
+		want    int
+	}{
+		{name: "resposta imediata", elapsed: 0, window: 30 * time.Second, want: 1000},
+		{name: "metade da janela", elapsed: 15 * time.Second, window: 30 * time.Second, want: 750},
+		{name: "um quarto da janela", elapsed: 5 * time.Second, window: 20 * time.Second, want: 875},
+		{name: "fim da janela", elapsed: 30 * time.Second, window: 30 * time.Second, want: 500},
+		{name: "depois da janela", elapsed: time.Minute, window: 30 * time.Second, want: 500},
+		{name: "janela inválida", elapsed: 10 * time.Second, window: 0, want: 1000},
+		{name: "tempo negativo", elapsed: -time.Second, window: 30 * time.Second, want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Points(tt.elapsed, tt.window); got != tt.want {
+				t.Fatalf("Points(%v, %v) = %d, esperado %d", tt.elapsed, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewScorer(t *testing.T) {
+	tests := []struct {
+		mode string
+		want Scorer
+	}{
+		{mode: quiz.PontuacaoFixa, want: FlatScorer{Value: PontosFixos}},
+		{mode: quiz.PontuacaoVelocidade, want: SpeedScorer{Max: PontosMaximos, Min: PontosMinimos}},
+		{mode: "", want: FlatScorer{Value: PontosFixos}}, // Quiz salvo antes do modo existir
+	}
+
+	for _, tt := range tests {
+		if got := NewScorer(tt.mode); got != tt.want {
+			t.Fatalf("NewScorer(%q) = %#v, esperado %#v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestStreakMultiplier(t *testing.T) {
+	tests := []struct {
+		streak int
+		want   float64
+	}{
+		{streak: 0, want: 1},
+		{streak: 1, want: 1},
+		{streak: 2, want: 1.1},
+		{streak: 3, want: 1.2},
+		{streak: 6, want: 1.5},
+		{streak: 20, want: MultiplicadorSequenciaMaximo},
+	}
+
+	for _, tt := range tests {
+		got := StreakMultiplier(tt.streak)
+		if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("StreakMultiplier(%d) = %v, esperado %v", tt.streak, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config contém as configurações da aplicação.
@@ -10,6 +11,14 @@ type Config struct {
 	Port      string
 	Database  DatabaseConfig
 	JWTSecret string
+	Rooms     RoomsConfig
+}
+
+// RoomsConfig define o ciclo de vida das salas ao vivo.
+type RoomsConfig struct {
+	IdleTTL       time.Duration // Sem atividade por este tempo, a sala é encerrada
+	MaxTTL        time.Duration // Tempo máximo de vida de uma sala
+	SweepInterval time.Duration // Intervalo da varredura de salas expiradas
 }
 
 type DatabaseConfig struct {
@@ -26,6 +35,11 @@ func Load() *Config {
 			DSN:    getEnv("DB_DSN", "./rankit.db"),
 		},
 		JWTSecret: getEnv("JWT_SECRET", "segredo_padrao_para_desenvolvimento"),
+		Rooms: RoomsConfig{
+			IdleTTL:       time.Duration(getEnvInt("ROOM_IDLE_TTL_MINUTES", 30)) * time.Minute,
+			MaxTTL:        time.Duration(getEnvInt("ROOM_MAX_TTL_MINUTES", 240)) * time.Minute,
+			SweepInterval: time.Duration(getEnvInt("ROOM_SWEEP_INTERVAL_SECONDS", 60)) * time.Second,
+		},
 	}
 }
 
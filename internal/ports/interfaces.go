@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"rankit/internal/domain/assignment"
 	"rankit/internal/domain/game"
 	"rankit/internal/domain/history"
 	"rankit/internal/domain/quiz"
@@ -39,6 +40,15 @@ type TokenService interface {
 	ValidateToken(tokenString string) (string, error)
 }
 
+// RejoinTokenService define o contrato dos tokens de reconexão de alunos.
+type RejoinTokenService interface {
+	// GenerateRejoinToken gera um token assinado que vincula o aluno à sala.
+	GenerateRejoinToken(roomID, playerID string) (string, error)
+
+	// ValidateRejoinToken valida o token e retorna a sala e o jogador vinculados.
+	ValidateRejoinToken(tokenString string) (roomID, playerID string, err error)
+}
+
 // QuizRepository define persistência para Quizzes.
 type QuizRepository interface {
 	Save(ctx context.Context, quiz *quiz.Quiz) error
@@ -59,15 +69,35 @@ type QuestionRepository interface {
 
 // GameRepository define persistência em memória para Salas de Jogo.
 type GameRepository interface {
+	// AddRoom registra uma sala recém-criada.
+	AddRoom(room *game.Room) error
+	// SaveRoom grava o estado de uma sala já registrada. Nunca recria uma sala removida por
+	// DeleteRoom: salas encerradas (game.Room.Closed) são ignoradas.
 	SaveRoom(room *game.Room) error
 	FindRoomByID(id string) (*game.Room, error)
+	// FindRoomByCode busca uma sala ativa pelo código de entrada (nil se não existir).
+	FindRoomByCode(code string) (*game.Room, error)
 	DeleteRoom(id string) error
+	ListRooms() ([]*game.Room, error)
 }
 
+// Papéis das conexões em tempo real, usados para escolher o público de cada evento
+const (
+	RoleTeacher   = "teacher"   // Professor dono da sala
+	RolePlayer    = "player"    // Aluno (ou candidato aguardando aprovação)
+	RoleSpectator = "spectator" // Telão/projetor: só acompanha, nunca joga
+)
+
 // RealTimeHub define contrato para envio de mensagens via WebSocket.
 type RealTimeHub interface {
 	BroadcastToRoom(roomID string, message interface{})
+	// BroadcastToRole envia apenas às conexões da sala com o papel indicado.
+	BroadcastToRole(roomID, role string, message interface{})
+	// BroadcastToRoleExcept envia às conexões da sala com o papel indicado, menos à do jogador informado.
+	BroadcastToRoleExcept(roomID, role, exceptPlayerID string, message interface{})
 	SendToPlayer(playerID string, message interface{})
+	// CloseRoom desconecta todos os clientes da sala e remove a sala do Hub.
+	CloseRoom(roomID string)
 }
 
 // HistoryRepository define persistência de histórico e relatórios.
@@ -77,3 +107,27 @@ type HistoryRepository interface {
 	GetByID(ctx context.Context, id string) (*history.RoomHistory, error)
 	GetQuizStats(ctx context.Context, quizID string) (map[string]interface{}, error) // Placeholder para retorno simples
 }
+
+// AssignmentRepository define persistência das tarefas (lição de casa) e das tentativas dos alunos.
+type AssignmentRepository interface {
+	Save(ctx context.Context, a *assignment.Assignment) error
+	Update(ctx context.Context, a *assignment.Assignment) error
+	// FindByID e FindByCode retornam nil (sem erro) se a tarefa não existir.
+	FindByID(ctx context.Context, id string) (*assignment.Assignment, error)
+	FindByCode(ctx context.Context, code string) (*assignment.Assignment, error)
+	FindByTeacherID(ctx context.Context, teacherID string) ([]*assignment.Assignment, error)
+	// FindUnarchived retorna as tarefas que ainda não foram gravadas no histórico.
+	FindUnarchived(ctx context.Context) ([]*assignment.Assignment, error)
+	// ClaimArchive grava o período e o historyId da tarefa apenas se ela ainda não foi arquivada.
+	// Retorna false se outra chamada arquivou antes.
+	ClaimArchive(ctx context.Context, a *assignment.Assignment) (bool, error)
+	// ReleaseArchive desfaz o ClaimArchive quando o histórico não pôde ser gravado.
+	ReleaseArchive(ctx context.Context, id, historyID string) error
+
+	SaveAttempt(ctx context.Context, t *assignment.Attempt) error
+	// FindAttemptByToken retorna a tentativa com as respostas já gravadas (nil se não existir).
+	FindAttemptByToken(ctx context.Context, token string) (*assignment.Attempt, error)
+	FindAttemptsByAssignmentID(ctx context.Context, assignmentID string) ([]*assignment.Attempt, error)
+	// SaveAnswer grava a resposta e o progresso da tentativa na mesma transação.
+	SaveAnswer(ctx context.Context, t *assignment.Attempt, ans *assignment.AttemptAnswer) error
+}
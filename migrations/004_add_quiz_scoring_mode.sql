@@ -0,0 +1,2 @@
+-- Modo de pontuação do quiz (FLAT = fixa, SPEED = por velocidade)
+ALTER TABLE quizzes ADD COLUMN scoring_mode TEXT NOT NULL DEFAULT 'FLAT';
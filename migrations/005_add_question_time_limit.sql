@@ -0,0 +1,2 @@
+-- Tempo limite por pergunta (0 = sem limite, revelação manual)
+ALTER TABLE questions ADD COLUMN time_limit_seconds INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- Estado das salas ao vivo (snapshot JSON), para retomar jogos após reinício do servidor
+CREATE TABLE IF NOT EXISTS live_rooms (
+    id TEXT PRIMARY KEY,
+    teacher_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    snapshot TEXT NOT NULL,
+    updated_at DATETIME NOT NULL
+);
@@ -0,0 +1,23 @@
+-- Tipos de pergunta com número variável de alternativas (JSON) e múltiplas respostas corretas
+ALTER TABLE questions ADD COLUMN type TEXT NOT NULL DEFAULT 'MULTIPLE_CHOICE';
+ALTER TABLE questions ADD COLUMN options TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE questions ADD COLUMN correct_indexes TEXT NOT NULL DEFAULT '[]';
+
+UPDATE questions
+SET options = json_array(option_a, option_b, option_c, option_d),
+    correct_indexes = json_array(correct_index);
+
+-- Histórico: contagem por alternativa e respostas com múltiplos índices
+ALTER TABLE room_questions ADD COLUMN question_type TEXT NOT NULL DEFAULT 'MULTIPLE_CHOICE';
+ALTER TABLE room_questions ADD COLUMN correct_indexes TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE room_questions ADD COLUMN option_counts TEXT NOT NULL DEFAULT '[]';
+
+UPDATE room_questions
+SET correct_indexes = json_array(correct_index),
+    option_counts = json_array(count_a, count_b, count_c, count_d);
+
+ALTER TABLE room_answers ADD COLUMN selected_indexes TEXT NOT NULL DEFAULT '[]';
+
+UPDATE room_answers
+SET selected_indexes = json_array(selected_index)
+WHERE selected_index >= 0;
@@ -0,0 +1,7 @@
+-- Perguntas de resposta aberta (lista de respostas aceitas) e numéricas (valor + tolerância)
+ALTER TABLE questions ADD COLUMN accepted_answers TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE questions ADD COLUMN numeric_answer REAL;
+ALTER TABLE questions ADD COLUMN tolerance REAL NOT NULL DEFAULT 0;
+
+-- Texto digitado pelo aluno, para revisão no relatório
+ALTER TABLE room_answers ADD COLUMN answer_text TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+-- Perguntas de ordenação (itens na ordem correta) e associação (duas colunas pareadas)
+ALTER TABLE questions ADD COLUMN items TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE questions ADD COLUMN match_left TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE questions ADD COLUMN match_right TEXT NOT NULL DEFAULT '[]';
+
+-- Respostas de ordenação e associação (índices originais da pergunta)
+ALTER TABLE room_answers ADD COLUMN answer_order TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE room_answers ADD COLUMN answer_pairs TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,17 @@
+-- Modo times: agregação usada na sala e time de cada aluno
+ALTER TABLE rooms_history ADD COLUMN team_scoring TEXT NOT NULL DEFAULT '';
+ALTER TABLE room_players ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+
+-- Resultado final de cada time
+CREATE TABLE IF NOT EXISTS room_teams (
+    id TEXT PRIMARY KEY,
+    room_history_id TEXT NOT NULL,
+    team_runtime_id TEXT NOT NULL, -- ID do time na sala ao vivo (referenciado por room_players.team_id)
+    name TEXT NOT NULL,
+    score INTEGER NOT NULL,
+    member_count INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (room_history_id) REFERENCES rooms_history (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_teams_room_history_id ON room_teams (room_history_id);
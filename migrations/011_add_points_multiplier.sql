@@ -0,0 +1,2 @@
+-- Multiplicador de pontos por pergunta (1 = normal, 2 = pontos em dobro)
+ALTER TABLE questions ADD COLUMN points_multiplier INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- Perguntas puladas pelo professor durante a sala (não pontuam)
+ALTER TABLE room_questions ADD COLUMN skipped BOOLEAN NOT NULL DEFAULT 0;
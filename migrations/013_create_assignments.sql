@@ -0,0 +1,50 @@
+-- Tarefas (lição de casa): quiz publicado respondido pelos alunos no próprio ritmo
+CREATE TABLE IF NOT EXISTS assignments (
+    id TEXT PRIMARY KEY,
+    teacher_id TEXT NOT NULL,
+    quiz_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    code TEXT NOT NULL UNIQUE, -- Código compartilhado com os alunos
+    opens_at DATETIME NOT NULL,
+    closes_at DATETIME NOT NULL,
+    history_id TEXT NOT NULL DEFAULT '', -- rooms_history gerado ao arquivar
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (teacher_id) REFERENCES teachers (id),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignments_teacher_id ON assignments (teacher_id);
+
+-- Tentativa de cada aluno (um apelido por tarefa)
+CREATE TABLE IF NOT EXISTS assignment_attempts (
+    id TEXT PRIMARY KEY,
+    assignment_id TEXT NOT NULL,
+    nickname TEXT NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    current_index INTEGER NOT NULL DEFAULT 0,
+    score INTEGER NOT NULL DEFAULT 0,
+    shuffle TEXT NOT NULL DEFAULT '[]', -- JSON: ordem exibida dos itens da pergunta atual
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    FOREIGN KEY (assignment_id) REFERENCES assignments (id) ON DELETE CASCADE,
+    UNIQUE (assignment_id, nickname)
+);
+
+-- Respostas, gravadas pergunta a pergunta
+CREATE TABLE IF NOT EXISTS assignment_answers (
+    id TEXT PRIMARY KEY,
+    attempt_id TEXT NOT NULL,
+    question_index INTEGER NOT NULL,
+    selected_indexes TEXT NOT NULL DEFAULT '[]', -- JSON
+    answer_text TEXT NOT NULL DEFAULT '',
+    answer_order TEXT NOT NULL DEFAULT '[]', -- JSON
+    answer_pairs TEXT NOT NULL DEFAULT '[]', -- JSON
+    credit REAL NOT NULL DEFAULT 0,
+    is_correct BOOLEAN NOT NULL,
+    points INTEGER NOT NULL,
+    answered_at DATETIME NOT NULL,
+    FOREIGN KEY (attempt_id) REFERENCES assignment_attempts (id) ON DELETE CASCADE,
+    UNIQUE (attempt_id, question_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_answers_attempt_id ON assignment_answers (attempt_id);
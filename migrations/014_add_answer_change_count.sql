@@ -0,0 +1,2 @@
+-- Quantas vezes o aluno trocou a resposta antes da revelação (política CHANGEABLE)
+ALTER TABLE room_answers ADD COLUMN change_count INTEGER NOT NULL DEFAULT 0;